@@ -73,6 +73,9 @@ type AsYouTypeFormatter struct {
 	extractedNationalPrefix string
 	nationalNumber          *stringbuilder.Builder
 	possibleFormats         []*NumberFormat
+
+	// phoneUtil is the Util the formatter reads its metadata through.
+	phoneUtil *Util
 }
 
 // separatorBeforeNationalNumber is the character used when appropriate to
@@ -120,13 +123,19 @@ var (
 )
 
 // GetAsYouTypeFormatter returns an AsYouTypeFormatter for the specific region.
+func (u *Util) GetAsYouTypeFormatter(regionCode string) *AsYouTypeFormatter {
+	return newAsYouTypeFormatter(regionCode, u)
+}
+
+// GetAsYouTypeFormatter calls Util.GetAsYouTypeFormatter on the default Util.
 func GetAsYouTypeFormatter(regionCode string) *AsYouTypeFormatter {
-	return newAsYouTypeFormatter(regionCode)
+	return DefaultUtil().GetAsYouTypeFormatter(regionCode)
 }
 
 // newAsYouTypeFormatter constructs an as-you-type formatter for the given region.
-func newAsYouTypeFormatter(regionCode string) *AsYouTypeFormatter {
+func newAsYouTypeFormatter(regionCode string, phoneUtil *Util) *AsYouTypeFormatter {
 	aytf := &AsYouTypeFormatter{
+		phoneUtil:                     phoneUtil,
 		ableToFormat:                  true,
 		defaultCountry:                regionCode,
 		accruedInput:                  stringbuilder.New(nil),
@@ -143,9 +152,9 @@ func newAsYouTypeFormatter(regionCode string) *AsYouTypeFormatter {
 // same for all regions sharing the same country calling code, so we return the
 // metadata for the "main" region for this country calling code.
 func (aytf *AsYouTypeFormatter) getMetadataForRegion(regionCode string) *PhoneMetadata {
-	countryCallingCode := GetCountryCodeForRegion(regionCode)
-	mainCountry := GetRegionCodeForCountryCode(countryCallingCode)
-	metadata := getMetadataForRegion(mainCountry)
+	countryCallingCode := aytf.phoneUtil.GetCountryCodeForRegion(regionCode)
+	mainCountry := aytf.phoneUtil.GetRegionCodeForCountryCode(countryCallingCode)
+	metadata := aytf.phoneUtil.getMetadataForRegion(mainCountry)
 	if metadata != nil {
 		return metadata
 	}
@@ -605,15 +614,15 @@ func (aytf *AsYouTypeFormatter) attemptToExtractCountryCallingCode() bool {
 		return false
 	}
 	numberWithoutCountryCallingCode := stringbuilder.New(nil)
-	countryCode := extractCountryCode(aytf.nationalNumber, numberWithoutCountryCallingCode)
+	countryCode := aytf.phoneUtil.extractCountryCode(aytf.nationalNumber, numberWithoutCountryCallingCode)
 	if countryCode == 0 {
 		return false
 	}
 	aytf.nationalNumber.Reset()
 	aytf.nationalNumber.Write(numberWithoutCountryCallingCode.Bytes())
-	newRegionCode := GetRegionCodeForCountryCode(countryCode)
+	newRegionCode := aytf.phoneUtil.GetRegionCodeForCountryCode(countryCode)
 	if REGION_CODE_FOR_NON_GEO_ENTITY == newRegionCode {
-		aytf.currentMetadata = getMetadataForNonGeographicalRegion(countryCode)
+		aytf.currentMetadata = aytf.phoneUtil.getMetadataForNonGeographicalRegion(countryCode)
 	} else if newRegionCode != aytf.defaultCountry {
 		aytf.currentMetadata = aytf.getMetadataForRegion(newRegionCode)
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"strconv"
@@ -9,12 +10,24 @@ import (
 )
 
 func main() {
-	if len(os.Args) != 3 {
-		fmt.Println("usage: phoneparser [number] [two letter country]")
+	trace := flag.Bool("trace", false, "print the steps taken to parse the number")
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		fmt.Println("usage: phoneparser [-trace] [number] [two letter country]")
 		os.Exit(1)
 	}
 
-	num, err := phonenumbers.Parse(os.Args[1], os.Args[2])
+	var num *phonenumbers.PhoneNumber
+	var err error
+	if *trace {
+		var steps *phonenumbers.ParseTrace
+		num, steps, err = phonenumbers.ParseWithTrace(flag.Arg(0), flag.Arg(1))
+		fmt.Print(steps)
+		fmt.Println()
+	} else {
+		num, err = phonenumbers.Parse(flag.Arg(0), flag.Arg(1))
+	}
 	if err != nil {
 		fmt.Printf("Error parsing number: %s\n", err)
 		os.Exit(1)
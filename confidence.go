@@ -0,0 +1,174 @@
+package phonenumbers
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MatchConfidence is how likely a match is to really be a phone number, and
+// the signals in the number and the text around it which that is based on.
+type MatchConfidence struct {
+	// Score is the confidence, from 0 for certainly not a number to 1 for
+	// certainly a number. It is a heuristic, meant for thresholds such as
+	// accepting matches above 0.8 and reviewing the rest, rather than a
+	// probability.
+	Score float64
+
+	// Keyword is the keyword near the match which suggests it is a number,
+	// e.g. "tel" or "fax", lowercased, or empty if there is none.
+	Keyword string
+
+	// International is whether the number was written with a plus sign or an
+	// international dialing prefix.
+	International bool
+
+	// ExactGrouping is whether the digits are grouped exactly as the number
+	// would be formatted, as EXACT_GROUPING requires.
+	ExactGrouping bool
+
+	// SingleBlock is whether the digits are written as one block, without
+	// any punctuation, as IDs often are.
+	SingleBlock bool
+
+	// Valid is whether the number is valid, which is only ever false for
+	// matches found with POSSIBLE leniency.
+	Valid bool
+
+	// LooksLikeDate is whether the match could be a date, e.g. "2011-02-03".
+	LooksLikeDate bool
+
+	// LooksLikePrice is whether the match is next to a currency symbol or
+	// code, e.g. "$ 650 253 0000".
+	LooksLikePrice bool
+
+	// LooksLikeID is whether the match follows a label such as "order" or
+	// "invoice", or a '#', as order and account IDs do.
+	LooksLikeID bool
+}
+
+// The weights of the signals in a MatchConfidence, added to the base score of
+// a valid number.
+const (
+	confidenceBase          = 0.5
+	confidenceKeyword       = 0.3
+	confidenceInternational = 0.15
+	confidenceExactGrouping = 0.1
+	confidenceSingleBlock   = -0.1
+	confidenceInvalid       = -0.2
+	confidenceDate          = -0.4
+	confidencePrice         = -0.4
+	confidenceID            = -0.4
+)
+
+// How far before and after a match keywords are looked for.
+const (
+	confidenceContextBefore = 40
+	confidenceContextAfter  = 20
+)
+
+var (
+	// confidenceKeywordPattern matches words which introduce numbers, in
+	// several languages. Words in scripts written with spaces must be whole.
+	confidenceKeywordPattern = regexp.MustCompile(`(?i)(?:^|[^\pL])(telephone|téléphone|telefone|teléfono|telefono|telefon|phone|tél|tel|tfn|tlf|fax|mobile|mobil|móvil|cellulare|cell|mob|handy|call|contact|whatsapp|sms)(?:[^\pL]|$)|(電話番号|電話|电话|手机|携帯|전화|휴대폰|ফোন|फ़ोन|फोन|телефон|тел)`)
+
+	// confidenceDatePattern matches dates written with separators, in either
+	// order, or as eight digits.
+	confidenceDatePattern = regexp.MustCompile(`^(?:[12]\d{3}[-./ ][01]?\d[-./ ][0-3]?\d|[0-3]?\d[-./ ][01]?\d[-./ ](?:[12]\d)?\d{2}|(?:19|20)\d{2}(?:0[1-9]|1[0-2])(?:0[1-9]|[12]\d|3[01]))$`)
+
+	// confidencePriceBeforePattern and confidencePriceAfterPattern match a
+	// currency symbol or code next to a match.
+	confidencePriceBeforePattern = regexp.MustCompile(`(?i)(?:\p{Sc}|(?:^|[^\pL])(?:usd|eur|gbp|inr|jpy|cny|aud|cad|chf|rs|kr))\s*$`)
+	confidencePriceAfterPattern  = regexp.MustCompile(`(?i)^\s*(?:\p{Sc}|(?:usd|eur|gbp|inr|jpy|cny|aud|cad|chf)(?:[^\pL]|$)|[.,]\d{2}(?:\D|$))`)
+
+	// confidenceIDPattern matches a label or '#' directly before a match.
+	confidenceIDPattern = regexp.MustCompile(`(?i)(?:#|(?:^|[^\pL])(?:order|invoice|inv|ref|reference|account|acct|tracking|ticket|case|id|sku|serial)\.?\s*[#:]?)\s*$`)
+)
+
+// matchConfidence returns the confidence of m, found in text.
+func (u *Util) matchConfidence(text string, m *PhoneNumberMatch) *MatchConfidence {
+	candidate := m.RawString()
+	c := &MatchConfidence{
+		Valid:         u.IsValidNumber(m.Number()),
+		International: m.countryCodeSource == PhoneNumber_FROM_NUMBER_WITH_PLUS_SIGN || m.countryCodeSource == PhoneNumber_FROM_NUMBER_WITH_IDD,
+	}
+	c.ExactGrouping = c.Valid && checkNumberGroupingIsValid(m.Number(), candidate, u, allNumberGroupsAreExactlyPresent)
+	c.SingleBlock = isSingleDigitBlock(candidate)
+
+	before := contextBefore(text, m.Start(), confidenceContextBefore)
+	after := contextAfter(text, m.End(), confidenceContextAfter)
+	if k := confidenceKeywordPattern.FindAllStringSubmatch(before, -1); k != nil {
+		c.Keyword = keywordOf(k[len(k)-1])
+	} else if k := confidenceKeywordPattern.FindStringSubmatch(after); k != nil {
+		c.Keyword = keywordOf(k)
+	}
+	c.LooksLikeDate = confidenceDatePattern.MatchString(normalizeDigits(candidate, true))
+	c.LooksLikePrice = confidencePriceBeforePattern.MatchString(before) || confidencePriceAfterPattern.MatchString(after)
+	c.LooksLikeID = confidenceIDPattern.MatchString(before)
+
+	c.Score = confidenceBase
+	for _, s := range []struct {
+		present bool
+		weight  float64
+	}{
+		{c.Keyword != "", confidenceKeyword},
+		{c.International, confidenceInternational},
+		{c.ExactGrouping && !c.SingleBlock, confidenceExactGrouping},
+		{c.SingleBlock, confidenceSingleBlock},
+		{!c.Valid, confidenceInvalid},
+		{c.LooksLikeDate, confidenceDate},
+		{c.LooksLikePrice, confidencePrice},
+		{c.LooksLikeID, confidenceID},
+	} {
+		if s.present {
+			c.Score += s.weight
+		}
+	}
+	c.Score = min(max(c.Score, 0), 1)
+	return c
+}
+
+// keywordOf returns the keyword matched by confidenceKeywordPattern.
+func keywordOf(submatches []string) string {
+	if submatches[1] != "" {
+		return strings.ToLower(submatches[1])
+	}
+	return submatches[2]
+}
+
+// isSingleDigitBlock reports whether candidate is only digits, other than a
+// leading plus sign.
+func isSingleDigitBlock(candidate string) bool {
+	digits := 0
+	for i, r := range candidate {
+		switch {
+		case i == 0 && strings.ContainsRune(plusChars, r):
+		case unicode.IsDigit(r):
+			digits++
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
+
+// contextBefore returns up to n bytes of text before offset, starting on a
+// rune boundary.
+func contextBefore(text string, offset, n int) string {
+	start := max(offset-n, 0)
+	for start < offset && !utf8.RuneStart(text[start]) {
+		start++
+	}
+	return text[start:offset]
+}
+
+// contextAfter returns up to n bytes of text after offset, ending on a rune
+// boundary.
+func contextAfter(text string, offset, n int) string {
+	end := min(offset+n, len(text))
+	for end > offset && end < len(text) && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	return text[offset:end]
+}
@@ -0,0 +1,88 @@
+package phonenumbers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchConfidence(t *testing.T) {
+	useTestMetadata(t)
+
+	tcs := []struct {
+		text       string
+		region     string
+		confidence MatchConfidence
+	}{
+		{"Tel: +44 20 7031 3000", regionCode.US, MatchConfidence{Score: 1, Keyword: "tel", International: true, ExactGrouping: true, Valid: true}},
+		{"電話 +44 20 7031 3000", regionCode.US, MatchConfidence{Score: 1, Keyword: "電話", International: true, ExactGrouping: true, Valid: true}},
+		{"Telephone: 650 2530000", regionCode.US, MatchConfidence{Score: 0.8, Keyword: "telephone", Valid: true}},
+		{"reach me on 650 253 0000 (fax)", regionCode.US, MatchConfidence{Score: 0.9, Keyword: "fax", ExactGrouping: true, Valid: true}},
+		{"6502530000", regionCode.US, MatchConfidence{Score: 0.4, ExactGrouping: true, SingleBlock: true, Valid: true}},
+		{"Tel No: 650 253 0000", regionCode.US, MatchConfidence{Score: 0.9, Keyword: "tel", ExactGrouping: true, Valid: true}},
+		{"Phone no. 650-253-0000", regionCode.US, MatchConfidence{Score: 0.9, Keyword: "phone", ExactGrouping: true, Valid: true}},
+		{"Tel: 011 44 20 7031 3000", regionCode.US, MatchConfidence{Score: 1, Keyword: "tel", International: true, ExactGrouping: true, Valid: true}},
+		{"Order #6502530000 shipped", regionCode.US, MatchConfidence{Score: 0, ExactGrouping: true, SingleBlock: true, Valid: true, LooksLikeID: true}},
+		{"Total: $ 650 253 0000", regionCode.US, MatchConfidence{Score: 0.2, ExactGrouping: true, Valid: true, LooksLikePrice: true}},
+		{"paid 650 253 0000 EUR", regionCode.US, MatchConfidence{Score: 0.2, ExactGrouping: true, Valid: true, LooksLikePrice: true}},
+		{"Phone: 2011-02-03", regionCode.DE, MatchConfidence{Score: 0.4, Keyword: "phone", Valid: true, LooksLikeDate: true}},
+		{"Phone: 20110203", regionCode.DE, MatchConfidence{Score: 0.3, Keyword: "phone", ExactGrouping: true, SingleBlock: true, Valid: true, LooksLikeDate: true}},
+	}
+	confidence := MatchOptions{Confidence: true}
+	for _, tc := range tcs {
+		found := slices.Collect(FindNumbersWithLeniency(tc.text, tc.region, POSSIBLE, math.MaxInt, confidence))
+		require.Len(t, found, 1, "matches in %s", tc.text)
+
+		c := *found[0].Confidence()
+		assert.InDelta(t, tc.confidence.Score, c.Score, 1e-9, "score for %s", tc.text)
+		c.Score = tc.confidence.Score
+		assert.Equal(t, tc.confidence, c, "confidence for %s", tc.text)
+	}
+
+	for m := range FindNumbers("Tel: +44 20 7031 3000", regionCode.US) {
+		assert.Nil(t, m.Confidence())
+	}
+
+	// confidence combines with offsets
+	both := slices.Collect(FindNumbersWithLeniency("電話 +44 20 7031 3000", regionCode.US, VALID, math.MaxInt, MatchOptions{Confidence: true, Offsets: true}))
+	require.Len(t, both, 1)
+	assert.Equal(t, "電話", both[0].Confidence().Keyword)
+	assert.Equal(t, 3, both[0].RuneStart())
+
+	// HTML matches are judged by the visible text around them
+	inHTML := slices.Collect(FindNumbersInHTML("<p>Tel: <b>+44</b> 20 7031 3000</p>", regionCode.US, VALID, math.MaxInt, confidence))
+	require.Len(t, inHTML, 1)
+	assert.Equal(t, "tel", inHTML[0].Confidence().Keyword)
+	assert.True(t, inHTML[0].Confidence().International)
+
+	// matches read from a stream have the confidence they would have had in
+	// the whole text, even at the start of a window
+	var sb strings.Builder
+	for i := 0; sb.Len() < 3*streamWindowSize; i++ {
+		fmt.Fprintf(&sb, "%s fax: 650 253 %04d, ", strings.Repeat("lorem ", i%11), i%10000)
+	}
+	text := sb.String()
+	var expected, actual []MatchConfidence
+	for m := range FindNumbersWithLeniency(text, regionCode.US, VALID, math.MaxInt, confidence) {
+		expected = append(expected, *m.Confidence())
+	}
+	for m, err := range FindNumbersInReader(strings.NewReader(text), regionCode.US, VALID, math.MaxInt, confidence) {
+		require.NoError(t, err)
+		actual = append(actual, *m.Confidence())
+	}
+	assert.Equal(t, expected, actual)
+
+	// and those found by a Finder have one too
+	finder := NewFinder(regionCode.US, VALID, FinderOptions{Match: confidence})
+	for result, err := range finder.Find(context.Background(), slices.Values([]string{"Tel: +44 20 7031 3000"})) {
+		require.NoError(t, err)
+		require.Len(t, result.Matches, 1)
+		assert.Equal(t, 1.0, result.Matches[0].Confidence().Score)
+	}
+}
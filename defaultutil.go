@@ -0,0 +1,342 @@
+package phonenumbers
+
+import (
+	"context"
+	"io"
+	"iter"
+)
+
+// The package-level API: each function below calls the Util method of the same
+// name on the default Util, which reads the active metadata container (see
+// DefaultUtil). They keep the long-standing function-style API working
+// unchanged; the documentation for each lives on the corresponding method.
+
+// GetLengthOfGeographicalAreaCode calls Util.GetLengthOfGeographicalAreaCode on the default Util.
+func GetLengthOfGeographicalAreaCode(number *PhoneNumber) int {
+	return DefaultUtil().GetLengthOfGeographicalAreaCode(number)
+}
+
+// GetLengthOfNationalDestinationCode calls Util.GetLengthOfNationalDestinationCode on the default Util.
+func GetLengthOfNationalDestinationCode(number *PhoneNumber) int {
+	return DefaultUtil().GetLengthOfNationalDestinationCode(number)
+}
+
+// GetSupportedRegions calls Util.GetSupportedRegions on the default Util.
+func GetSupportedRegions() map[string]bool {
+	return DefaultUtil().GetSupportedRegions()
+}
+
+// GetSupportedCallingCodes calls Util.GetSupportedCallingCodes on the default Util.
+func GetSupportedCallingCodes() map[int]bool {
+	return DefaultUtil().GetSupportedCallingCodes()
+}
+
+// GetSupportedGlobalNetworkCallingCodes calls Util.GetSupportedGlobalNetworkCallingCodes on the default Util.
+func GetSupportedGlobalNetworkCallingCodes() map[int]bool {
+	return DefaultUtil().GetSupportedGlobalNetworkCallingCodes()
+}
+
+// GetSupportedTypesForRegion calls Util.GetSupportedTypesForRegion on the default Util.
+func GetSupportedTypesForRegion(regionCode string) map[PhoneNumberType]bool {
+	return DefaultUtil().GetSupportedTypesForRegion(regionCode)
+}
+
+// GetSupportedTypesForNonGeoEntity calls Util.GetSupportedTypesForNonGeoEntity on the default Util.
+func GetSupportedTypesForNonGeoEntity(countryCallingCode int) map[PhoneNumberType]bool {
+	return DefaultUtil().GetSupportedTypesForNonGeoEntity(countryCallingCode)
+}
+
+// IsNumberGeographical calls Util.IsNumberGeographical on the default Util.
+func IsNumberGeographical(phoneNumber *PhoneNumber) bool {
+	return DefaultUtil().IsNumberGeographical(phoneNumber)
+}
+
+// Format calls Util.Format on the default Util.
+func Format(number *PhoneNumber, numberFormat PhoneNumberFormat) string {
+	return DefaultUtil().Format(number, numberFormat)
+}
+
+// FormatByPattern calls Util.FormatByPattern on the default Util.
+func FormatByPattern(number *PhoneNumber, numberFormat PhoneNumberFormat, userDefinedFormats []*NumberFormat) string {
+	return DefaultUtil().FormatByPattern(number, numberFormat, userDefinedFormats)
+}
+
+// FormatNationalNumberWithCarrierCode calls Util.FormatNationalNumberWithCarrierCode on the default Util.
+func FormatNationalNumberWithCarrierCode(number *PhoneNumber, carrierCode string) string {
+	return DefaultUtil().FormatNationalNumberWithCarrierCode(number, carrierCode)
+}
+
+// FormatNationalNumberWithPreferredCarrierCode calls Util.FormatNationalNumberWithPreferredCarrierCode on the default Util.
+func FormatNationalNumberWithPreferredCarrierCode(number *PhoneNumber, fallbackCarrierCode string) string {
+	return DefaultUtil().FormatNationalNumberWithPreferredCarrierCode(number, fallbackCarrierCode)
+}
+
+// FormatNumberForMobileDialing calls Util.FormatNumberForMobileDialing on the default Util.
+func FormatNumberForMobileDialing(number *PhoneNumber, regionCallingFrom string, withFormatting bool) string {
+	return DefaultUtil().FormatNumberForMobileDialing(number, regionCallingFrom, withFormatting)
+}
+
+// FormatOutOfCountryCallingNumber calls Util.FormatOutOfCountryCallingNumber on the default Util.
+func FormatOutOfCountryCallingNumber(number *PhoneNumber, regionCallingFrom string) string {
+	return DefaultUtil().FormatOutOfCountryCallingNumber(number, regionCallingFrom)
+}
+
+// FormatInOriginalFormat calls Util.FormatInOriginalFormat on the default Util.
+func FormatInOriginalFormat(number *PhoneNumber, regionCallingFrom string) string {
+	return DefaultUtil().FormatInOriginalFormat(number, regionCallingFrom)
+}
+
+// FormatOutOfCountryKeepingAlphaChars calls Util.FormatOutOfCountryKeepingAlphaChars on the default Util.
+func FormatOutOfCountryKeepingAlphaChars(number *PhoneNumber, regionCallingFrom string) string {
+	return DefaultUtil().FormatOutOfCountryKeepingAlphaChars(number, regionCallingFrom)
+}
+
+// Redact calls Util.Redact on the default Util.
+func Redact(number *PhoneNumber, policy RedactPolicy) string {
+	return DefaultUtil().Redact(number, policy)
+}
+
+// GetExampleNumber calls Util.GetExampleNumber on the default Util.
+func GetExampleNumber(regionCode string) *PhoneNumber {
+	return DefaultUtil().GetExampleNumber(regionCode)
+}
+
+// GetInvalidExampleNumber calls Util.GetInvalidExampleNumber on the default Util.
+func GetInvalidExampleNumber(regionCode string) *PhoneNumber {
+	return DefaultUtil().GetInvalidExampleNumber(regionCode)
+}
+
+// GetExampleNumberForTypeInRegion calls Util.GetExampleNumberForTypeInRegion on the default Util.
+func GetExampleNumberForTypeInRegion(regionCode string, typ PhoneNumberType) *PhoneNumber {
+	return DefaultUtil().GetExampleNumberForTypeInRegion(regionCode, typ)
+}
+
+// GetExampleNumberForType calls Util.GetExampleNumberForType on the default Util.
+func GetExampleNumberForType(typ PhoneNumberType) *PhoneNumber {
+	return DefaultUtil().GetExampleNumberForType(typ)
+}
+
+// GetExampleNumberForNonGeoEntity calls Util.GetExampleNumberForNonGeoEntity on the default Util.
+func GetExampleNumberForNonGeoEntity(countryCallingCode int) *PhoneNumber {
+	return DefaultUtil().GetExampleNumberForNonGeoEntity(countryCallingCode)
+}
+
+// GetNumberType calls Util.GetNumberType on the default Util.
+func GetNumberType(number *PhoneNumber) PhoneNumberType {
+	return DefaultUtil().GetNumberType(number)
+}
+
+// IsValidNumber calls Util.IsValidNumber on the default Util.
+func IsValidNumber(number *PhoneNumber) bool {
+	return DefaultUtil().IsValidNumber(number)
+}
+
+// IsValidNumberForRegion calls Util.IsValidNumberForRegion on the default Util.
+func IsValidNumberForRegion(number *PhoneNumber, regionCode string) bool {
+	return DefaultUtil().IsValidNumberForRegion(number, regionCode)
+}
+
+// GetRegionCodeForNumber calls Util.GetRegionCodeForNumber on the default Util.
+func GetRegionCodeForNumber(number *PhoneNumber) string {
+	return DefaultUtil().GetRegionCodeForNumber(number)
+}
+
+// GetRegionCodeForCountryCode calls Util.GetRegionCodeForCountryCode on the default Util.
+func GetRegionCodeForCountryCode(countryCallingCode int) string {
+	return DefaultUtil().GetRegionCodeForCountryCode(countryCallingCode)
+}
+
+// GetRegionCodesForCountryCode calls Util.GetRegionCodesForCountryCode on the default Util.
+func GetRegionCodesForCountryCode(countryCallingCode int) []string {
+	return DefaultUtil().GetRegionCodesForCountryCode(countryCallingCode)
+}
+
+// GetCountryCodeForRegion calls Util.GetCountryCodeForRegion on the default Util.
+func GetCountryCodeForRegion(regionCode string) int {
+	return DefaultUtil().GetCountryCodeForRegion(regionCode)
+}
+
+// GetNddPrefixForRegion calls Util.GetNddPrefixForRegion on the default Util.
+func GetNddPrefixForRegion(regionCode string, stripNonDigits bool) string {
+	return DefaultUtil().GetNddPrefixForRegion(regionCode, stripNonDigits)
+}
+
+// IsNANPACountry calls Util.IsNANPACountry on the default Util.
+func IsNANPACountry(regionCode string) bool {
+	return DefaultUtil().IsNANPACountry(regionCode)
+}
+
+// IsPossibleNumber calls Util.IsPossibleNumber on the default Util.
+func IsPossibleNumber(number *PhoneNumber) bool {
+	return DefaultUtil().IsPossibleNumber(number)
+}
+
+// IsPossibleNumberFromRegion calls Util.IsPossibleNumberFromRegion on the default Util.
+func IsPossibleNumberFromRegion(number string, regionDialingFrom string) bool {
+	return DefaultUtil().IsPossibleNumberFromRegion(number, regionDialingFrom)
+}
+
+// IsPossibleNumberWithReason calls Util.IsPossibleNumberWithReason on the default Util.
+func IsPossibleNumberWithReason(number *PhoneNumber) ValidationResult {
+	return DefaultUtil().IsPossibleNumberWithReason(number)
+}
+
+// IsPossibleNumberForTypeWithReason calls Util.IsPossibleNumberForTypeWithReason on the default Util.
+func IsPossibleNumberForTypeWithReason(number *PhoneNumber, numberType PhoneNumberType) ValidationResult {
+	return DefaultUtil().IsPossibleNumberForTypeWithReason(number, numberType)
+}
+
+// IsPossibleNumberForType calls Util.IsPossibleNumberForType on the default Util.
+func IsPossibleNumberForType(number *PhoneNumber, numberType PhoneNumberType) bool {
+	return DefaultUtil().IsPossibleNumberForType(number, numberType)
+}
+
+// TruncateTooLongNumber calls Util.TruncateTooLongNumber on the default Util.
+func TruncateTooLongNumber(number *PhoneNumber) bool {
+	return DefaultUtil().TruncateTooLongNumber(number)
+}
+
+// Parse calls Util.Parse on the default Util.
+func Parse(numberToParse, defaultRegion string) (*PhoneNumber, error) {
+	return DefaultUtil().Parse(numberToParse, defaultRegion)
+}
+
+// ParseToNumber calls Util.ParseToNumber on the default Util.
+func ParseToNumber(numberToParse, defaultRegion string, phoneNumber *PhoneNumber) error {
+	return DefaultUtil().ParseToNumber(numberToParse, defaultRegion, phoneNumber)
+}
+
+// ParseAndKeepRawInput calls Util.ParseAndKeepRawInput on the default Util.
+func ParseAndKeepRawInput(numberToParse, defaultRegion string) (*PhoneNumber, error) {
+	return DefaultUtil().ParseAndKeepRawInput(numberToParse, defaultRegion)
+}
+
+// ParseAndKeepRawInputToNumber calls Util.ParseAndKeepRawInputToNumber on the default Util.
+func ParseAndKeepRawInputToNumber(numberToParse, defaultRegion string, phoneNumber *PhoneNumber) error {
+	return DefaultUtil().ParseAndKeepRawInputToNumber(numberToParse, defaultRegion, phoneNumber)
+}
+
+// ParseWithTrace calls Util.ParseWithTrace on the default Util.
+func ParseWithTrace(numberToParse, defaultRegion string) (*PhoneNumber, *ParseTrace, error) {
+	return DefaultUtil().ParseWithTrace(numberToParse, defaultRegion)
+}
+
+// ParseWithOptions calls Util.ParseWithOptions on the default Util.
+func ParseWithOptions(numberToParse string, opts ParseOptions) (*PhoneNumber, error) {
+	return DefaultUtil().ParseWithOptions(numberToParse, opts)
+}
+
+// ParseBatch calls Util.ParseBatch on the default Util.
+func ParseBatch(ctx context.Context, inputs iter.Seq[string], defaultRegion string, opts BatchOptions) iter.Seq2[*PhoneNumber, error] {
+	return DefaultUtil().ParseBatch(ctx, inputs, defaultRegion, opts)
+}
+
+// ParseWithCandidateRegions calls Util.ParseWithCandidateRegions on the default Util.
+func ParseWithCandidateRegions(numberToParse string, regions []string) ([]*ParseCandidate, error) {
+	return DefaultUtil().ParseWithCandidateRegions(numberToParse, regions)
+}
+
+// FindNumbers calls Util.FindNumbers on the default Util.
+func FindNumbers(text, defaultRegion string) iter.Seq[*PhoneNumberMatch] {
+	return DefaultUtil().FindNumbers(text, defaultRegion)
+}
+
+// FindNumbersWithLeniency calls Util.FindNumbersWithLeniency on the default Util.
+func FindNumbersWithLeniency(text, defaultRegion string, leniency Leniency, maxTries int, opts ...MatchOptions) iter.Seq[*PhoneNumberMatch] {
+	return DefaultUtil().FindNumbersWithLeniency(text, defaultRegion, leniency, maxTries, opts...)
+}
+
+// FindNumbersWithVerifier calls Util.FindNumbersWithVerifier on the default Util.
+func FindNumbersWithVerifier(text, defaultRegion string, verifier Verifier, maxTries int, opts ...MatchOptions) iter.Seq[*PhoneNumberMatch] {
+	return DefaultUtil().FindNumbersWithVerifier(text, defaultRegion, verifier, maxTries, opts...)
+}
+
+// FindNumbersInHTML calls Util.FindNumbersInHTML on the default Util.
+func FindNumbersInHTML(doc, defaultRegion string, leniency Leniency, maxTries int, opts ...MatchOptions) iter.Seq[*PhoneNumberMatch] {
+	return DefaultUtil().FindNumbersInHTML(doc, defaultRegion, leniency, maxTries, opts...)
+}
+
+// FindNumbersInReader calls Util.FindNumbersInReader on the default Util.
+func FindNumbersInReader(r io.Reader, defaultRegion string, leniency Leniency, maxTries int, opts ...MatchOptions) iter.Seq2[*PhoneNumberMatch, error] {
+	return DefaultUtil().FindNumbersInReader(r, defaultRegion, leniency, maxTries, opts...)
+}
+
+// RedactNumbers calls Util.RedactNumbers on the default Util.
+func RedactNumbers(text, defaultRegion string, leniency Leniency, replacer func(*PhoneNumberMatch) string) string {
+	return DefaultUtil().RedactNumbers(text, defaultRegion, leniency, replacer)
+}
+
+// NewFinder calls Util.NewFinder on the default Util.
+func NewFinder(defaultRegion string, verifier Verifier, opts FinderOptions) *Finder {
+	return DefaultUtil().NewFinder(defaultRegion, verifier, opts)
+}
+
+// Linkify calls Util.Linkify on the default Util.
+func Linkify(text, defaultRegion string, opts LinkifyOptions) string {
+	return DefaultUtil().Linkify(text, defaultRegion, opts)
+}
+
+// IsNumberMatch calls Util.IsNumberMatch on the default Util.
+func IsNumberMatch(firstNumber, secondNumber string) MatchType {
+	return DefaultUtil().IsNumberMatch(firstNumber, secondNumber)
+}
+
+// IsNumberMatchWithOneNumber calls Util.IsNumberMatchWithOneNumber on the default Util.
+func IsNumberMatchWithOneNumber(firstNumber *PhoneNumber, secondNumber string) MatchType {
+	return DefaultUtil().IsNumberMatchWithOneNumber(firstNumber, secondNumber)
+}
+
+// CanBeInternationallyDialled calls Util.CanBeInternationallyDialled on the default Util.
+func CanBeInternationallyDialled(number *PhoneNumber) bool {
+	return DefaultUtil().CanBeInternationallyDialled(number)
+}
+
+// IsMobileNumberPortableRegion calls Util.IsMobileNumberPortableRegion on the default Util.
+func IsMobileNumberPortableRegion(regionCode string) bool {
+	return DefaultUtil().IsMobileNumberPortableRegion(regionCode)
+}
+
+// IsPossibleShortNumber calls Util.IsPossibleShortNumber on the default Util.
+func IsPossibleShortNumber(number *PhoneNumber) bool {
+	return DefaultUtil().IsPossibleShortNumber(number)
+}
+
+// IsPossibleShortNumberForRegion calls Util.IsPossibleShortNumberForRegion on the default Util.
+func IsPossibleShortNumberForRegion(number *PhoneNumber, regionDialingFrom string) bool {
+	return DefaultUtil().IsPossibleShortNumberForRegion(number, regionDialingFrom)
+}
+
+// IsValidShortNumber calls Util.IsValidShortNumber on the default Util.
+func IsValidShortNumber(number *PhoneNumber) bool {
+	return DefaultUtil().IsValidShortNumber(number)
+}
+
+// IsValidShortNumberForRegion calls Util.IsValidShortNumberForRegion on the default Util.
+func IsValidShortNumberForRegion(number *PhoneNumber, regionDialingFrom string) bool {
+	return DefaultUtil().IsValidShortNumberForRegion(number, regionDialingFrom)
+}
+
+// GetExpectedCostForRegion calls Util.GetExpectedCostForRegion on the default Util.
+func GetExpectedCostForRegion(number *PhoneNumber, regionDialingFrom string) ShortNumberCost {
+	return DefaultUtil().GetExpectedCostForRegion(number, regionDialingFrom)
+}
+
+// GetExpectedCost calls Util.GetExpectedCost on the default Util.
+func GetExpectedCost(number *PhoneNumber) ShortNumberCost {
+	return DefaultUtil().GetExpectedCost(number)
+}
+
+// IsCarrierSpecific calls Util.IsCarrierSpecific on the default Util.
+func IsCarrierSpecific(number *PhoneNumber) bool {
+	return DefaultUtil().IsCarrierSpecific(number)
+}
+
+// IsCarrierSpecificForRegion calls Util.IsCarrierSpecificForRegion on the default Util.
+func IsCarrierSpecificForRegion(number *PhoneNumber, regionDialingFrom string) bool {
+	return DefaultUtil().IsCarrierSpecificForRegion(number, regionDialingFrom)
+}
+
+// IsSmsServiceForRegion calls Util.IsSmsServiceForRegion on the default Util.
+func IsSmsServiceForRegion(number *PhoneNumber, regionDialingFrom string) bool {
+	return DefaultUtil().IsSmsServiceForRegion(number, regionDialingFrom)
+}
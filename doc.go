@@ -8,5 +8,9 @@
 // to keep the port easy to verify against upstream. The aim is strictly to
 // match libphonenumber's functionality rather than to add to it.
 //
+// The package-level functions read the embedded metadata. Util binds the same
+// API to an explicit metadata.Container, the analogue of a PhoneNumberUtil
+// instance upstream, so several metadata versions can be used side by side.
+//
 // See SYNC.md for which upstream version each ported file is reconciled against.
 package phonenumbers
@@ -0,0 +1,109 @@
+package phonenumbers
+
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// parsedNumber lets Parse allocate a PhoneNumber together with the fields the
+// E164 fast path sets, so that parsing an E164 number takes one allocation.
+type parsedNumber struct {
+	number         PhoneNumber
+	countryCode    int32
+	nationalNumber uint64
+}
+
+// parseE164 is the fast path for parsing input already in E164 format, i.e. a
+// plus sign followed only by ASCII digits, which is by far the most common
+// input for services storing numbers. Such input needs none of the regular
+// expressions parseHelper applies to find the number in free text, so this
+// parses it without allocating, leaving the caller to set the fields of a
+// PhoneNumber. It only handles numbers it can parse exactly
+// as parseHelper would, returning false for anything else: unknown country
+// calling codes, lengths parseHelper would reject, national significant numbers
+// with leading zeros, and numbers that might start with a national prefix.
+func (u *Util) parseE164(numberToParse string) (countryCode int32, nationalNumber uint64, ok bool) {
+	if len(numberToParse) < 2 || len(numberToParse) > maxInputStringLength || numberToParse[0] != plusSign {
+		return 0, 0, false
+	}
+	digits := numberToParse[1:]
+	if len(digits) <= minLengthForNSN || digits[0] == '0' {
+		return 0, 0, false
+	}
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return 0, 0, false
+		}
+	}
+
+	// as extractCountryCode, the shortest prefix which is a known country
+	// calling code is the country calling code
+	cc, ccLen := 0, 0
+	for i := 1; i <= maxLengthCountryCode && i <= len(digits); i++ {
+		cc = cc*10 + int(digits[i-1]-'0')
+		if _, ok := u.container.CountryCodeToRegion()[cc]; ok {
+			ccLen = i
+			break
+		}
+	}
+	if ccLen == 0 {
+		return 0, 0, false
+	}
+
+	nsn := digits[ccLen:]
+	if len(nsn) < minLengthForNSN || len(nsn) > maxLengthForNSN || nsn[0] == '0' {
+		return 0, 0, false
+	}
+	regionMetadata := u.getMetadataForRegionOrCallingCode(cc, u.GetRegionCodeForCountryCode(cc))
+	if regionMetadata == nil || u.container.NationalPrefixDigits(regionMetadata).MayStart(nsn) {
+		return 0, 0, false
+	}
+
+	for i := 0; i < len(nsn); i++ {
+		nationalNumber = nationalNumber*10 + uint64(nsn[i]-'0')
+	}
+	return int32(cc), nationalNumber, true
+}
+
+// setE164Fields sets the fields of phoneNumber parsed by parseE164. This
+// allocates new fields, as phoneNumber's may be shared with other numbers.
+func setE164Fields(phoneNumber *PhoneNumber, countryCode int32, nationalNumber uint64) {
+	phoneNumber.CountryCode = proto.Int32(countryCode)
+	phoneNumber.NationalNumber = proto.Uint64(nationalNumber)
+	phoneNumber.ItalianLeadingZero = nil
+}
+
+// setE164 sets the fields of p's number parsed by parseE164, pointing them at
+// p's own storage rather than allocating.
+func (p *parsedNumber) setE164(countryCode int32, nationalNumber uint64) {
+	p.countryCode, p.nationalNumber = countryCode, nationalNumber
+	p.number.CountryCode, p.number.NationalNumber = &p.countryCode, &p.nationalNumber
+	p.number.ItalianLeadingZero = nil
+}
+
+// parse parses numberToParse into p's number, as ParseToNumber would but
+// taking the E164 fast path without allocating.
+func (u *Util) parse(p *parsedNumber, numberToParse, defaultRegion string) error {
+	if countryCode, nationalNumber, ok := u.parseE164(numberToParse); ok {
+		p.setE164(countryCode, nationalNumber)
+		return nil
+	}
+	var progress parseProgress
+	err := u.parseHelper(numberToParse, defaultRegion, false, true, &p.number, &progress)
+	return u.parseError(err, numberToParse, defaultRegion, progress.offset)
+}
+
+// appendE164 appends number in E164 format to dst. It is the fast path for
+// Format, as E164 involves no formatting rules.
+func appendE164(dst []byte, number *PhoneNumber) []byte {
+	dst = append(dst, plusSign)
+	dst = strconv.AppendInt(dst, int64(number.GetCountryCode()), 10)
+	// as GetNationalSignificantNumber, leading zeros are clamped to maxLengthForNSN
+	if number.GetItalianLeadingZero() {
+		for range min(int(number.GetNumberOfLeadingZeros()), maxLengthForNSN) {
+			dst = append(dst, '0')
+		}
+	}
+	return strconv.AppendUint(dst, number.GetNationalNumber(), 10)
+}
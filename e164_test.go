@@ -0,0 +1,175 @@
+package phonenumbers
+
+import (
+	"testing"
+
+	"github.com/nyaruka/phonenumbers/v2/internal/stringbuilder"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// e164ExampleNumbers returns the example numbers of every type for every
+// region and non-geographical entity, in E164 format.
+func e164ExampleNumbers() []string {
+	var numbers []string
+	for region := range GetSupportedRegions() {
+		for numberType := range GetSupportedTypesForRegion(region) {
+			if num := GetExampleNumberForTypeInRegion(region, numberType); num != nil {
+				numbers = append(numbers, Format(num, E164))
+			}
+		}
+	}
+	for callingCode := range GetSupportedGlobalNetworkCallingCodes() {
+		if num := GetExampleNumberForNonGeoEntity(callingCode); num != nil {
+			numbers = append(numbers, Format(num, E164))
+		}
+	}
+	return numbers
+}
+
+func TestParseE164FastPath(t *testing.T) {
+	u := DefaultUtil()
+
+	inputs := append(e164ExampleNumbers(),
+		"+16502530000",
+		"+390236618300",     // Italian leading zero
+		"+4407700900123",    // GB national prefix
+		"+8001234567",       // non-geographical
+		"+1", "+16", "+165", // too short
+		"+0123456789",          // no country calling code
+		"+9991234567",          // unknown country calling code
+		"+1650253000012345678", // too long
+		"+1 650 253 0000",
+		"+１６５０２５３００００",
+	)
+
+	fast := 0
+	for _, input := range inputs {
+		expected := &PhoneNumber{}
+		var progress parseProgress
+		expectedErr := u.parseError(u.parseHelper(input, regionCode.US, false, true, expected, &progress), input, regionCode.US, progress.offset)
+
+		if _, _, ok := u.parseE164(input); ok {
+			fast++
+		}
+
+		actual, err := Parse(input, regionCode.US)
+		assert.Equal(t, expectedErr, err, "error mismatch for input %q", input)
+		assert.True(t, proto.Equal(expected, actual), "Parse mismatch for input %q", input)
+
+		// reusing a number parsed from different input
+		reused := &PhoneNumber{CountryCode: proto.Int32(64), NationalNumber: proto.Uint64(33316005), ItalianLeadingZero: proto.Bool(true)}
+		err = ParseToNumber(input, regionCode.US, reused)
+		assert.Equal(t, expectedErr, err, "error mismatch for input %q", input)
+		if err == nil {
+			assert.True(t, proto.Equal(expected, reused), "ParseToNumber mismatch for input %q", input)
+		}
+	}
+	assert.Greater(t, fast, len(inputs)/2)
+}
+
+func TestFormatE164FastPath(t *testing.T) {
+	u := DefaultUtil()
+
+	numbers := []*PhoneNumber{
+		{CountryCode: proto.Int32(1), NationalNumber: proto.Uint64(6502530000)},
+		{CountryCode: proto.Int32(39), NationalNumber: proto.Uint64(236618300), ItalianLeadingZero: proto.Bool(true)},
+		{CountryCode: proto.Int32(39), NationalNumber: proto.Uint64(0), ItalianLeadingZero: proto.Bool(true), NumberOfLeadingZeros: proto.Int32(3)},
+		{CountryCode: proto.Int32(39), NationalNumber: proto.Uint64(1), ItalianLeadingZero: proto.Bool(true), NumberOfLeadingZeros: proto.Int32(1 << 30)},
+		{CountryCode: proto.Int32(39), NationalNumber: proto.Uint64(1), ItalianLeadingZero: proto.Bool(true), NumberOfLeadingZeros: proto.Int32(-1)},
+		{CountryCode: proto.Int32(999), NationalNumber: proto.Uint64(12345), Extension: proto.String("123")},
+		{NationalNumber: proto.Uint64(12345)},
+		{},
+	}
+	for _, input := range e164ExampleNumbers() {
+		num, err := Parse(input, regionCode.ZZ)
+		assert.NoError(t, err)
+		numbers = append(numbers, num)
+	}
+
+	for _, num := range numbers {
+		expected := stringbuilder.New(nil)
+		u.formatWithBuf(num, E164, expected)
+		assert.Equal(t, expected.String(), Format(num, E164), "format mismatch for %v", num)
+	}
+}
+
+func TestE164Allocations(t *testing.T) {
+	num := &PhoneNumber{}
+	assert.NoError(t, ParseToNumber("+16502530000", regionCode.US, num))
+
+	// the fields of a reused number are replaced, not written through, so
+	// numbers sharing them are unaffected
+	shared := &PhoneNumber{CountryCode: num.CountryCode, NationalNumber: num.NationalNumber}
+	assert.NoError(t, ParseToNumber("+442083661177", regionCode.US, num))
+	assert.Equal(t, "+16502530000", Format(shared, E164))
+	assert.Equal(t, "+442083661177", Format(num, E164))
+
+	assert.Equal(t, 2.0, testing.AllocsPerRun(100, func() { ParseToNumber("+442083661177", regionCode.US, num) }))
+	assert.Equal(t, 1.0, testing.AllocsPerRun(100, func() { Parse("+442083661177", regionCode.US) }))
+	assert.Equal(t, 1.0, testing.AllocsPerRun(100, func() { Format(num, E164) }))
+
+	p := &parsedNumber{}
+	assert.Equal(t, 0.0, testing.AllocsPerRun(100, func() { DefaultUtil().parse(p, "+442083661177", regionCode.US) }))
+}
+
+var benchmarkInputs = []struct {
+	name  string
+	input string
+}{
+	{"E164FastPath", "+442083661177"},
+	{"International", "+44 20 8366 1177"},
+	{"National", "020 8366 1177"},
+	{"Text", "Tel: (020) 8366-1177 ext. 123"},
+}
+
+// BenchmarkParse makes one allocation on the E164 fast path, for the returned
+// number.
+func BenchmarkParse(b *testing.B) {
+	for _, bm := range benchmarkInputs {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				Parse(bm.input, regionCode.GB)
+			}
+		})
+	}
+}
+
+// BenchmarkParseToNumber makes two allocations on the E164 fast path, for the
+// country calling code and national number fields.
+func BenchmarkParseToNumber(b *testing.B) {
+	for _, bm := range benchmarkInputs {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			num := &PhoneNumber{}
+			for b.Loop() {
+				ParseToNumber(bm.input, regionCode.GB, num)
+			}
+		})
+	}
+}
+
+// BenchmarkFormat makes one allocation on the E164 fast path, for the
+// returned string.
+func BenchmarkFormat(b *testing.B) {
+	num, _ := Parse("+442083661177", regionCode.GB)
+
+	formats := []struct {
+		name   string
+		format PhoneNumberFormat
+	}{
+		{"E164FastPath", E164},
+		{"International", INTERNATIONAL},
+		{"National", NATIONAL},
+		{"RFC3966", RFC3966},
+	}
+	for _, bm := range formats {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				Format(num, bm.format)
+			}
+		})
+	}
+}
@@ -114,45 +114,70 @@ const (
 	EXACT_GROUPING
 )
 
+// Verify reports whether number, parsed from candidate, satisfies the leniency
+// level, reading metadata through the default Util.
 func (l Leniency) Verify(number *PhoneNumber, candidate string) bool {
+	return l.verify(number, candidate, DefaultUtil())
+}
+
+// VerifyNumber implements Verifier.
+func (l Leniency) VerifyNumber(u *Util, number *PhoneNumber, candidate string) bool {
+	return l.verify(number, candidate, u)
+}
 
+// verify is Verify against the metadata of util, mirroring upstream's
+// verify(number, candidate, util, matcher).
+func (l Leniency) verify(number *PhoneNumber, candidate string, util *Util) bool {
 	switch l {
 	case POSSIBLE:
-		return IsPossibleNumber(number)
+		return util.IsPossibleNumber(number)
 	case VALID:
-		if !IsValidNumber(number) ||
-			!containsOnlyValidXChars(number, candidate) {
+		if !util.IsValidNumber(number) ||
+			!containsOnlyValidXChars(number, candidate, util) {
 			return false
 		}
-		return isNationalPrefixPresentIfRequired(number)
+		return isNationalPrefixPresentIfRequired(number, util)
 	case STRICT_GROUPING:
-		if !IsValidNumber(number) ||
-			!containsOnlyValidXChars(number, candidate) ||
+		if !util.IsValidNumber(number) ||
+			!containsOnlyValidXChars(number, candidate, util) ||
 			containsMoreThanOneSlashInNationalNumber(number, candidate) ||
-			!isNationalPrefixPresentIfRequired(number) {
+			!isNationalPrefixPresentIfRequired(number, util) {
 			return false
 		}
-		return checkNumberGroupingIsValid(number, candidate,
-			func(number *PhoneNumber,
+		return checkNumberGroupingIsValid(number, candidate, util,
+			func(util *Util,
+				number *PhoneNumber,
 				normalizedCandidate string,
 				expectedNumberGroups []string) bool {
 				return allNumberGroupsRemainGrouped(
-					number, normalizedCandidate, expectedNumberGroups)
+					util, number, normalizedCandidate, expectedNumberGroups)
 			})
 	case EXACT_GROUPING:
-		if !IsValidNumber(number) ||
-			!containsOnlyValidXChars(number, candidate) ||
+		if !util.IsValidNumber(number) ||
+			!containsOnlyValidXChars(number, candidate, util) ||
 			containsMoreThanOneSlashInNationalNumber(number, candidate) ||
-			!isNationalPrefixPresentIfRequired(number) {
+			!isNationalPrefixPresentIfRequired(number, util) {
 			return false
 		}
-		return checkNumberGroupingIsValid(number, candidate,
-			func(number *PhoneNumber,
+		return checkNumberGroupingIsValid(number, candidate, util,
+			func(util *Util,
+				number *PhoneNumber,
 				normalizedCandidate string,
 				expectedNumberGroups []string) bool {
 				return allNumberGroupsAreExactlyPresent(
-					number, normalizedCandidate, expectedNumberGroups)
+					util, number, normalizedCandidate, expectedNumberGroups)
 			})
 	}
 	return false
 }
+
+// loosestFailed returns the loosest level, up to l, which number, parsed from
+// candidate, fails to satisfy, given that it fails l.
+func (l Leniency) loosestFailed(number *PhoneNumber, candidate string, util *Util) Leniency {
+	for level := POSSIBLE; level < l; level++ {
+		if !level.verify(number, candidate, util) {
+			return level
+		}
+	}
+	return l
+}
@@ -4,6 +4,7 @@ package phonenumbers
 import (
 	"errors"
 
+	"github.com/nyaruka/phonenumbers/v2/internal/character"
 	"github.com/nyaruka/phonenumbers/v2/metadata"
 )
 
@@ -15,3 +16,170 @@ var ErrTooShortAfterIDD = errors.New("phone number had an IDD, but " +
 	"after this was not long enough to be a viable phone number")
 
 var ErrNumTooLong = errors.New("the string supplied is too long to be a phone number")
+
+// ErrorType is the type of a ParseError, as per NumberParseException.ErrorType.
+type ErrorType int
+
+const (
+	// ErrorType_INVALID_COUNTRY_CODE:
+	//     The country code supplied did not belong to a supported country or
+	//     non-geographical entity.
+	// ErrorType_NOT_A_NUMBER:
+	//     This generally indicates the string passed in had less than 3 digits
+	//     in it. More specifically, the number failed to match the regular
+	//     expression VALID_PHONE_NUMBER in PhoneNumberUtil.
+	// ErrorType_TOO_SHORT_AFTER_IDD:
+	//     This indicates the string started with an international dialing
+	//     prefix, but after this was stripped from the number, had less digits
+	//     than any valid phone number (including extensions) could have.
+	// ErrorType_TOO_SHORT_NSN:
+	//     This indicates the string, after any country code has been stripped,
+	//     had less digits than any valid phone number could have.
+	// ErrorType_TOO_LONG:
+	//     This indicates the string had more digits than any valid phone
+	//     number could have.
+	ErrorType_INVALID_COUNTRY_CODE ErrorType = iota
+	ErrorType_NOT_A_NUMBER
+	ErrorType_TOO_SHORT_AFTER_IDD
+	ErrorType_TOO_SHORT_NSN
+	ErrorType_TOO_LONG
+)
+
+var errorTypeNames = [...]string{
+	ErrorType_INVALID_COUNTRY_CODE: "INVALID_COUNTRY_CODE",
+	ErrorType_NOT_A_NUMBER:         "NOT_A_NUMBER",
+	ErrorType_TOO_SHORT_AFTER_IDD:  "TOO_SHORT_AFTER_IDD",
+	ErrorType_TOO_SHORT_NSN:        "TOO_SHORT_NSN",
+	ErrorType_TOO_LONG:             "TOO_LONG",
+}
+
+// String returns the upstream name of the error type, e.g. "TOO_SHORT_NSN".
+func (t ErrorType) String() string {
+	if t < 0 || int(t) >= len(errorTypeNames) {
+		return "UNKNOWN"
+	}
+	return errorTypeNames[t]
+}
+
+// ParseError is the error returned by the Parse family of functions, the
+// counterpart of upstream's NumberParseException. It unwraps to the sentinel
+// error for its type (ErrInvalidCountryCode, ErrNotANumber, ErrTooShortAfterIDD,
+// ErrTooShortNSN or ErrNumTooLong), so errors.Is works as it always has, and
+// its message is the sentinel's.
+type ParseError struct {
+	// Type is the kind of failure.
+	Type ErrorType
+
+	// Input is the string that was being parsed.
+	Input string
+
+	// Region is the default region the input was parsed against.
+	Region string
+
+	// Offset is the byte offset in Input at which parsing failed: 0 for empty
+	// input, the maximum input length for over-long input, just after the
+	// international dialing prefix or plus sign for TOO_SHORT_AFTER_IDD and
+	// for INVALID_COUNTRY_CODE when one is present, and otherwise the start of
+	// the number in Input (after any leading text or "tel:" prefix), or
+	// len(Input) if none was found.
+	Offset int
+
+	err error
+}
+
+func (e *ParseError) Error() string { return e.err.Error() }
+
+// Unwrap returns the sentinel error for the error's type.
+func (e *ParseError) Unwrap() error { return e.err }
+
+var parseErrorTypes = map[error]ErrorType{
+	ErrInvalidCountryCode: ErrorType_INVALID_COUNTRY_CODE,
+	ErrNotANumber:         ErrorType_NOT_A_NUMBER,
+	ErrTooShortAfterIDD:   ErrorType_TOO_SHORT_AFTER_IDD,
+	ErrTooShortNSN:        ErrorType_TOO_SHORT_NSN,
+	ErrNumTooLong:         ErrorType_TOO_LONG,
+}
+
+// parseError wraps a sentinel error from parseHelper in a ParseError
+// describing where, at offset, parsing of numberToParse failed. Any other
+// error, including nil, is returned unchanged.
+func (u *Util) parseError(err error, numberToParse, defaultRegion string, offset int) error {
+	errType, ok := parseErrorTypes[err]
+	if !ok {
+		return err
+	}
+
+	return &ParseError{
+		Type:   errType,
+		Input:  numberToParse,
+		Region: defaultRegion,
+		Offset: offset,
+		err:    err,
+	}
+}
+
+// parseProgress records how far parseHelper got through its input, giving
+// the offset for a ParseError if it fails, and optionally traces its steps.
+type parseProgress struct {
+	// offset is the byte offset in the input at which parsing is reported
+	// to have failed.
+	offset int
+
+	// number is the byte offset in the input of the number being parsed, or
+	// -1 if that isn't a slice of the input, as when it was prefixed with an
+	// RFC3966 phone-context.
+	number int
+
+	// trace, if non-nil, records the steps parsing takes.
+	trace *ParseTrace
+}
+
+// tracer returns the trace to record steps in, if any.
+func (p *parseProgress) tracer() *ParseTrace {
+	if p == nil {
+		return nil
+	}
+	return p.trace
+}
+
+// skip moves the start of the number being parsed n bytes on.
+func (p *parseProgress) skip(n int) {
+	if p != nil && p.number >= 0 {
+		p.number += n
+	}
+}
+
+// strippedPrefix moves the offset to just after the international prefix or
+// plus sign stripped from the start of number, prefix being the plus signs
+// themselves or the normalized international prefix digits.
+func (p *parseProgress) strippedPrefix(number string, countryCodeSource PhoneNumber_CountryCodeSource, prefix string) {
+	if p == nil || p.number < 0 {
+		return
+	}
+	if countryCodeSource == PhoneNumber_FROM_NUMBER_WITH_PLUS_SIGN {
+		p.offset = p.number + len(prefix)
+		return
+	}
+
+	iddDigits := len(prefix)
+	for i, r := range number {
+		if iddDigits == 0 {
+			p.offset = p.number + i
+			return
+		}
+		if _, isDigit := character.Digit(r); isDigit {
+			iddDigits--
+		}
+	}
+	p.offset = p.number + len(number)
+}
+
+// Errors returned by ParseWithOptions for input that parses but which its
+// options don't accept.
+var (
+	ErrInvalidNumber        = errors.New("the phone number supplied is not a valid number")
+	ErrRegionNotAllowed     = errors.New("the phone number supplied is from a region that is not allowed")
+	ErrNumberTypeNotAllowed = errors.New("the phone number supplied is of a type that is not allowed")
+	ErrExtensionNotAllowed  = errors.New("the phone number supplied has an extension, which is not allowed")
+	ErrAlphaNotAllowed      = errors.New("the phone number supplied contains letters, which is not allowed")
+)
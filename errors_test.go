@@ -0,0 +1,47 @@
+package phonenumbers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseErrorDetails(t *testing.T) {
+	useTestMetadata(t)
+
+	tests := []struct {
+		input   string
+		region  string
+		errType ErrorType
+		offset  int
+	}{
+		{"", regionCode.US, ErrorType_NOT_A_NUMBER, 0},
+		{"This is not a phone number", regionCode.NZ, ErrorType_NOT_A_NUMBER, 26},
+		{"tel:555-1234;phone-context=1-331", regionCode.ZZ, ErrorType_NOT_A_NUMBER, 12},
+		{"+210 3456 56789", regionCode.NZ, ErrorType_INVALID_COUNTRY_CODE, 1},
+		{"123 456 7890", regionCode.ZZ, ErrorType_INVALID_COUNTRY_CODE, 0},
+		{"tel:555-1234;phone-context=www.google.com", regionCode.ZZ, ErrorType_INVALID_COUNTRY_CODE, 4},
+		{"call 0044------", regionCode.GB, ErrorType_TOO_SHORT_AFTER_IDD, 7},
+		{"011", regionCode.US, ErrorType_TOO_SHORT_AFTER_IDD, 3},
+		{"Tel: +49 0", regionCode.DE, ErrorType_TOO_SHORT_NSN, 5},
+		{"01495 72553301873 810104", regionCode.GB, ErrorType_TOO_LONG, 0},
+		{strings.Repeat("1", 251), regionCode.US, ErrorType_TOO_LONG, 250},
+	}
+	for _, tc := range tests {
+		_, err := Parse(tc.input, tc.region)
+
+		var pe *ParseError
+		if assert.ErrorAs(t, err, &pe, "input %q", tc.input) {
+			assert.Equal(t, tc.errType, pe.Type, "type mismatch for input %q", tc.input)
+			assert.Equal(t, tc.input, pe.Input)
+			assert.Equal(t, tc.region, pe.Region)
+			assert.Equal(t, tc.offset, pe.Offset, "offset mismatch for input %q", tc.input)
+		}
+	}
+
+	_, err := ParseAndKeepRawInput("0044", regionCode.GB)
+	assert.ErrorIs(t, err, ErrTooShortAfterIDD)
+	assert.EqualError(t, err, ErrTooShortAfterIDD.Error())
+	assert.Equal(t, "TOO_SHORT_AFTER_IDD", err.(*ParseError).Type.String())
+}
@@ -99,7 +99,7 @@ func TestSharedCost(t *testing.T) {
 func TestCanBeInternationallyDialledExampleNumbers(t *testing.T) {
 	var wrongTypeCases []*PhoneNumber
 	for regionCode := range GetSupportedRegions() {
-		desc := getMetadataForRegion(regionCode).GetNoInternationalDialling()
+		desc := DefaultUtil().getMetadataForRegion(regionCode).GetNoInternationalDialling()
 		if desc.GetExampleNumber() == "" {
 			continue
 		}
@@ -161,7 +161,7 @@ func getExampleNumberForTypeAnyRegion(t *testing.T, typ PhoneNumberType) *PhoneN
 		}
 	}
 	for callingCode := range GetSupportedGlobalNetworkCallingCodes() {
-		desc := getNumberDescByType(getMetadataForNonGeographicalRegion(callingCode), typ)
+		desc := getNumberDescByType(DefaultUtil().getMetadataForNonGeographicalRegion(callingCode), typ)
 		if desc.GetExampleNumber() == "" {
 			continue
 		}
@@ -0,0 +1,204 @@
+package phonenumbers
+
+import (
+	"context"
+	"iter"
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// FinderOptions are the options for a Finder.
+type FinderOptions struct {
+	// Workers is the number of goroutines scanning documents in parallel,
+	// defaulting to GOMAXPROCS.
+	Workers int
+
+	// MaxTries is the maximum number of invalid candidates tried in each
+	// document, as for FindNumbersWithLeniency. Zero means no limit.
+	MaxTries int
+
+	// TotalTries is the maximum number of invalid candidates tried across all
+	// the documents of a Find. Zero means no limit.
+	TotalTries int
+
+	// Timeout is how long a Find may spend scanning documents. Zero means no
+	// limit.
+	Timeout time.Duration
+
+	// Match are the options for finding numbers in each document.
+	Match MatchOptions
+}
+
+// FinderStats are the statistics for the scan of one document.
+type FinderStats struct {
+	// Candidates is the number of candidates verified, including the parts
+	// of longer candidates tried when the whole isn't a number.
+	Candidates int
+
+	// Malformed is the number of candidates which couldn't be numbers, e.g.
+	// because of unmatched brackets, or which didn't parse.
+	Malformed int
+
+	// Rejected is the number of candidates which the verifier rejected.
+	Rejected int
+
+	// RejectedByLeniency counts the rejected candidates by the loosest level
+	// they fail, when the verifier is a Leniency.
+	RejectedByLeniency map[Leniency]int
+}
+
+// FinderResult is the result of scanning one document.
+type FinderResult struct {
+	// Index is the position of the document in the sequence given to Find.
+	Index int
+
+	// Matches are the numbers found in the document.
+	Matches []*PhoneNumberMatch
+
+	Stats FinderStats
+
+	// Truncated is whether the scan stopped before the end of the document,
+	// because it ran out of tries or time. Documents reached after the Find
+	// has run out of TotalTries or Timeout aren't scanned at all, so are
+	// truncated with no matches.
+	Truncated bool
+}
+
+// Finder finds numbers in many documents concurrently, e.g. chat transcripts,
+// bounding the work done across all of them. A Finder is safe for concurrent
+// use.
+type Finder struct {
+	util          *Util
+	defaultRegion string
+	verifier      Verifier
+	opts          FinderOptions
+}
+
+// NewFinder returns a Finder which finds numbers as FindNumbersWithVerifier
+// would with defaultRegion and verifier, e.g. a Leniency.
+func (u *Util) NewFinder(defaultRegion string, verifier Verifier, opts FinderOptions) *Finder {
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.GOMAXPROCS(0)
+	}
+	if opts.MaxTries <= 0 {
+		opts.MaxTries = math.MaxInt
+	}
+	return &Finder{util: u, defaultRegion: defaultRegion, verifier: verifier, opts: opts}
+}
+
+// finderJob is a document being scanned.
+type finderJob struct {
+	text   string
+	result FinderResult
+	done   chan struct{}
+}
+
+// Find scans each of docs for numbers across a pool of workers, yielding the
+// result for each in input order. If ctx is cancelled, scanning stops and
+// iteration ends by yielding ctx.Err().
+//
+//	finder := phonenumbers.NewFinder("US", phonenumbers.VALID, phonenumbers.FinderOptions{TotalTries: 100000})
+//	for result, err := range finder.Find(ctx, transcripts) {
+//		...
+//	}
+func (f *Finder) Find(ctx context.Context, docs iter.Seq[string]) iter.Seq2[*FinderResult, error] {
+	return func(yield func(*FinderResult, error) bool) {
+		// scanning stops when the budget runs out, or iteration ends early
+		var scanCtx context.Context
+		var cancel context.CancelFunc
+		if f.opts.Timeout > 0 {
+			scanCtx, cancel = context.WithTimeout(ctx, f.opts.Timeout)
+		} else {
+			scanCtx, cancel = context.WithCancel(ctx)
+		}
+		var budget *atomic.Int64
+		if f.opts.TotalTries > 0 {
+			budget = &atomic.Int64{}
+			budget.Store(int64(f.opts.TotalTries))
+		}
+
+		numQueued := f.opts.Workers * 2
+		queued := make(chan *finderJob, numQueued)
+		jobs := make(chan *finderJob, numQueued)
+		stop := make(chan struct{})
+
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		defer close(stop)
+		defer cancel()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(jobs)
+			defer close(queued)
+
+			i := 0
+			for doc := range docs {
+				job := &finderJob{text: doc, result: FinderResult{Index: i}, done: make(chan struct{})}
+				select {
+				case queued <- job:
+				case <-stop:
+					return
+				case <-ctx.Done():
+					return
+				}
+				jobs <- job
+				i++
+			}
+		}()
+
+		for range f.opts.Workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for job := range jobs {
+					f.scan(scanCtx, budget, job)
+					close(job.done)
+				}
+			}()
+		}
+
+		for {
+			var job *finderJob
+			select {
+			case job = <-queued:
+			case <-ctx.Done():
+				yield(nil, ctx.Err())
+				return
+			}
+			if job == nil {
+				// the producer may have stopped because of cancellation
+				if ctx.Err() != nil {
+					yield(nil, ctx.Err())
+				}
+				return
+			}
+			<-job.done
+
+			// a scan interrupted by cancellation isn't a result
+			if ctx.Err() != nil {
+				yield(nil, ctx.Err())
+				return
+			}
+			if !yield(&job.result, nil) {
+				return
+			}
+		}
+	}
+}
+
+// scan finds the numbers in the document of job.
+func (f *Finder) scan(ctx context.Context, budget *atomic.Int64, job *finderJob) {
+	m := newPhoneNumberMatcher(f.util, job.text, f.defaultRegion, f.verifier, f.opts.MaxTries)
+	m.setOptions([]MatchOptions{f.opts.Match})
+	m.ctx, m.budget, m.stats = ctx, budget, &job.result.Stats
+	for m.hasNext() {
+		job.result.Matches = append(job.result.Matches, m.next())
+	}
+	job.result.Truncated = m.interrupted || m.maxTries <= 0
+}
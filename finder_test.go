@@ -0,0 +1,91 @@
+package phonenumbers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinder(t *testing.T) {
+	useTestMetadata(t)
+
+	docs := make([]string, 100)
+	for i := range docs {
+		docs[i] = fmt.Sprintf("Call 650 253 %04d or 650 2530 %03d, ref 12-34 (2003), not 1234%d.", i, i, i)
+	}
+
+	var found []string
+	finder := NewFinder(regionCode.US, STRICT_GROUPING, FinderOptions{Workers: 4})
+	for result, err := range finder.Find(context.Background(), slices.Values(docs)) {
+		require.NoError(t, err)
+		assert.Len(t, found, result.Index)
+		assert.False(t, result.Truncated)
+
+		var want []string
+		for m := range FindNumbersWithLeniency(docs[result.Index], regionCode.US, STRICT_GROUPING, math.MaxInt) {
+			want = append(want, m.RawString())
+		}
+		var got []string
+		for _, m := range result.Matches {
+			got = append(got, m.RawString())
+		}
+		assert.Equal(t, want, got)
+		assert.Equal(t, []string{fmt.Sprintf("650 253 %04d", result.Index)}, got)
+
+		// the badly grouped number is valid but not strictly grouped,
+		// "12-34 (2003)" looks like a page range, and the rest, e.g. "1234",
+		// aren't possible numbers
+		stats := result.Stats
+		assert.Equal(t, 1, stats.RejectedByLeniency[STRICT_GROUPING])
+		assert.Zero(t, stats.RejectedByLeniency[VALID])
+		assert.GreaterOrEqual(t, stats.Malformed, 1)
+		assert.Equal(t, stats.Rejected, stats.RejectedByLeniency[POSSIBLE]+stats.RejectedByLeniency[STRICT_GROUPING])
+		assert.Equal(t, stats.Candidates, 1+stats.Malformed+stats.Rejected)
+
+		found = append(found, got[0])
+	}
+	assert.Len(t, found, len(docs))
+
+	// the total tries are shared by all documents, so the later ones aren't scanned
+	finder = NewFinder(regionCode.US, STRICT_GROUPING, FinderOptions{Workers: 1, TotalTries: 10})
+	truncated := 0
+	for result, err := range finder.Find(context.Background(), slices.Values(docs)) {
+		require.NoError(t, err)
+		if result.Truncated {
+			truncated++
+		}
+	}
+	assert.Greater(t, truncated, 90)
+
+	// as they aren't once out of time
+	finder = NewFinder(regionCode.US, STRICT_GROUPING, FinderOptions{Timeout: time.Nanosecond})
+	for result, err := range finder.Find(context.Background(), slices.Values(docs)) {
+		require.NoError(t, err)
+		assert.True(t, result.Truncated)
+		assert.Empty(t, result.Matches)
+	}
+
+	// iteration ends on cancellation
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var results int
+	var errs []error
+	for _, err := range finder.Find(ctx, slices.Values(docs)) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		results++
+		if results == 5 {
+			cancel()
+		}
+	}
+	assert.Equal(t, 5, results)
+	assert.Equal(t, []error{context.Canceled}, errs)
+}
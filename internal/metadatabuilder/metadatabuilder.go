@@ -3,7 +3,8 @@
 // This is build-time-only machinery: it compiles upstream's metadata XML into
 // the proto types that cmd/buildmetadata serializes into the embedded data. It
 // lives in internal/ — mirroring upstream's separate tools module — so it stays
-// off the public API surface; only cmd/buildmetadata and tests consume it.
+// off the public API surface; only cmd/buildmetadata, tests and the opt-in
+// metadata/metadataxml loader consume it.
 //
 // The proto value types live in the metadata package; the *E types below are
 // the XML-element shapes this builder unmarshals into. Locals that hold a built
@@ -41,7 +42,7 @@ func BuildPhoneMetadataCollection(inputXML []byte, liteBuild bool, specialBuild
 	doc := &PhoneNumberMetadataE{}
 	err := xml.Unmarshal(inputXML, doc)
 	if err != nil {
-		panic(fmt.Sprintf("Error unmarshalling XML: %s", err))
+		return nil, fmt.Errorf("error unmarshalling XML: %w", err)
 	}
 	isAlternateFormatsMetadata := false
 	return buildPhoneMetadataFromElement(doc, liteBuild, specialBuild, isShortNumberMetadata, isAlternateFormatsMetadata)
@@ -55,7 +56,7 @@ func BuildAlternateFormatsMetadataCollection(inputXML []byte) (*metadata.PhoneMe
 	doc := &PhoneNumberMetadataE{}
 	err := xml.Unmarshal(inputXML, doc)
 	if err != nil {
-		panic(fmt.Sprintf("Error unmarshalling XML: %s", err))
+		return nil, fmt.Errorf("error unmarshalling XML: %w", err)
 	}
 	return buildPhoneMetadataFromElement(doc, false, false, false, true)
 }
@@ -67,7 +68,10 @@ func buildPhoneMetadataFromElement(document *PhoneNumberMetadataE, liteBuild boo
 		territoryElement := document.Territories[i]
 		regionCode := territoryElement.ID
 
-		md := loadCountryMetadata(regionCode, &territoryElement, isShortNumberMetadata, isAlternateFormatsMetadata)
+		md, err := loadCountryMetadata(regionCode, &territoryElement, isShortNumberMetadata, isAlternateFormatsMetadata)
+		if err != nil {
+			return nil, err
+		}
 		if isAlternateFormatsMetadata && md.Id == nil {
 			// Alternate-formats territories carry no region id; upstream still sets
 			// the (required) id field to the empty string. sp() maps "" to nil, so
@@ -85,32 +89,10 @@ func buildPhoneMetadataFromElement(document *PhoneNumberMetadataE, liteBuild boo
 // such as the NANPA countries, the one indicated with "isMainCountryForCode" in the metadata
 // should be first.
 func BuildCountryCodeToRegionMap(metadataCollection *metadata.PhoneMetadataCollection) map[int][]string {
-	countryCodeToRegionCodeMap := make(map[int][]string)
-	for _, md := range metadataCollection.Metadata {
-		regionCode := md.GetId()
-		countryCode := int(md.GetCountryCode())
-		_, present := countryCodeToRegionCodeMap[countryCode]
-		if present {
-			phoneList := countryCodeToRegionCodeMap[countryCode]
-			if md.GetMainCountryForCode() {
-				phoneList = append([]string{regionCode}, phoneList...)
-			} else {
-				phoneList = append(phoneList, regionCode)
-			}
-			countryCodeToRegionCodeMap[countryCode] = phoneList
-		} else {
-			// For most countries, there will be only one region code for the country calling code.
-			phoneList := []string{}
-			if regionCode != "" { // For alternate formats, there are no region codes at all.
-				phoneList = append(phoneList, regionCode)
-			}
-			countryCodeToRegionCodeMap[countryCode] = phoneList
-		}
-	}
-	return countryCodeToRegionCodeMap
+	return metadata.BuildCountryCodeToRegionMap(metadataCollection)
 }
 
-func validateRE(re string, removeWhitespace bool) string {
+func validateRE(re string, removeWhitespace bool) (string, error) {
 	// Removes all the whitespace and newline from the regexp. Not Ming pattern compile options to
 	// make it work across programming languages.
 	if removeWhitespace {
@@ -118,32 +100,49 @@ func validateRE(re string, removeWhitespace bool) string {
 	}
 	_, err := regexp.Compile(re)
 	if err != nil {
-		panic(err)
+		return "", err
+	}
+	return re, nil
+}
+
+// validREs validates each of res as validateRE does, returning the first error.
+func validREs(removeWhitespace bool, res ...*string) error {
+	for _, re := range res {
+		valid, err := validateRE(*re, removeWhitespace)
+		if err != nil {
+			return err
+		}
+		*re = valid
 	}
-	return re
+	return nil
 }
 
-func loadTerritoryTagMetadata(regionCode string, territory *TerritoryE, nationalPrefix string) *metadata.PhoneMetadata {
+func loadTerritoryTagMetadata(regionCode string, territory *TerritoryE, nationalPrefix string) (*metadata.PhoneMetadata, error) {
 	md := &metadata.PhoneMetadata{}
 	md.Id = sp(regionCode)
 
 	if territory.CountryCode != 0 {
 		md.CountryCode = ip(territory.CountryCode)
 	}
-	if territory.LeadingDigits != "" {
-		md.LeadingDigits = sp(validateRE(territory.LeadingDigits, false))
-	}
-	if territory.InternationalPrefix != "" {
-		md.InternationalPrefix = sp(validateRE(territory.InternationalPrefix, false))
+	leadingDigits, internationalPrefix := territory.LeadingDigits, territory.InternationalPrefix
+	if err := validREs(false, &leadingDigits, &internationalPrefix); err != nil {
+		return nil, err
 	}
+	md.LeadingDigits = sp(leadingDigits)
+	md.InternationalPrefix = sp(internationalPrefix)
 	if territory.PreferredInternationalPrefix != "" {
 		md.PreferredInternationalPrefix = sp(territory.PreferredInternationalPrefix)
 	}
 	if territory.NationalPrefixForParsing != "" {
-		md.NationalPrefixForParsing = sp(validateRE(territory.NationalPrefixForParsing, true))
-		if territory.NationalPrefixTransformRule != "" {
-			md.NationalPrefixTransformRule = sp(validateRE(territory.NationalPrefixTransformRule, false))
+		nationalPrefixForParsing, nationalPrefixTransformRule := territory.NationalPrefixForParsing, territory.NationalPrefixTransformRule
+		if err := validREs(true, &nationalPrefixForParsing); err != nil {
+			return nil, err
 		}
+		if err := validREs(false, &nationalPrefixTransformRule); err != nil {
+			return nil, err
+		}
+		md.NationalPrefixForParsing = sp(nationalPrefixForParsing)
+		md.NationalPrefixTransformRule = sp(nationalPrefixTransformRule)
 	}
 	if nationalPrefix != "" {
 		md.NationalPrefix = sp(nationalPrefix)
@@ -160,15 +159,18 @@ func loadTerritoryTagMetadata(regionCode string, territory *TerritoryE, national
 	if territory.MobileNumberPortableRegion {
 		md.MobileNumberPortableRegion = bp(true)
 	}
-	return md
+	return md, nil
 }
 
-func setLeadingDigitsPatterns(numberFormatElement *NumberFormatE, format *metadata.NumberFormat) {
-	if len(numberFormatElement.LeadingDigits) > 0 {
-		for i := 0; i < len(numberFormatElement.LeadingDigits); i++ {
-			format.LeadingDigitsPattern = append(format.LeadingDigitsPattern, validateRE(numberFormatElement.LeadingDigits[i], true))
+func setLeadingDigitsPatterns(numberFormatElement *NumberFormatE, format *metadata.NumberFormat) error {
+	for i := 0; i < len(numberFormatElement.LeadingDigits); i++ {
+		leadingDigits, err := validateRE(numberFormatElement.LeadingDigits[i], true)
+		if err != nil {
+			return err
 		}
+		format.LeadingDigitsPattern = append(format.LeadingDigitsPattern, leadingDigits)
 	}
+	return nil
 }
 
 /**
@@ -178,20 +180,22 @@ func setLeadingDigitsPatterns(numberFormatElement *NumberFormatE, format *metada
  * @throws  RuntimeException if multiple intlFormats have been encountered.
  * @return  whether an international number format is defined.
  */
-func loadInternationalFormat(md *metadata.PhoneMetadata, numberFormatElement *NumberFormatE, nationalFormat *metadata.NumberFormat) bool {
+func loadInternationalFormat(md *metadata.PhoneMetadata, numberFormatElement *NumberFormatE, nationalFormat *metadata.NumberFormat) (bool, error) {
 	intlFormat := &metadata.NumberFormat{}
 	intlFormatPattern := numberFormatElement.InternationalFormat
 	hasExplicitIntlFormatDefined := false
 
 	if len(intlFormatPattern) > 1 {
-		panic("Invalid number of intlFormat patterns for country: " + md.GetId())
+		return false, fmt.Errorf("invalid number of intlFormat patterns for country: %s", md.GetId())
 
 	} else if len(intlFormatPattern) == 0 {
 		// Default to use the same as the national pattern if none is defined.
 		intlFormat.Merge(nationalFormat)
 	} else {
 		intlFormat.Pattern = sp(numberFormatElement.Pattern)
-		setLeadingDigitsPatterns(numberFormatElement, intlFormat)
+		if err := setLeadingDigitsPatterns(numberFormatElement, intlFormat); err != nil {
+			return false, err
+		}
 		intlFormatPatternValue := intlFormatPattern[0]
 		if intlFormatPatternValue != "NA" {
 			intlFormat.Format = sp(intlFormatPatternValue)
@@ -202,7 +206,7 @@ func loadInternationalFormat(md *metadata.PhoneMetadata, numberFormatElement *Nu
 	if intlFormat.Format != nil {
 		md.IntlNumberFormat = append(md.IntlNumberFormat, intlFormat)
 	}
-	return hasExplicitIntlFormatDefined
+	return hasExplicitIntlFormatDefined, nil
 }
 
 /**
@@ -211,10 +215,17 @@ func loadInternationalFormat(md *metadata.PhoneMetadata, numberFormatElement *Nu
  * @throws  RuntimeException if multiple or no formats have been encountered.
  */
 // @VisibleForTesting
-func loadNationalFormat(md *metadata.PhoneMetadata, numberFormatElement *NumberFormatE, format *metadata.NumberFormat) {
-	setLeadingDigitsPatterns(numberFormatElement, format)
-	format.Pattern = sp(validateRE(numberFormatElement.Pattern, false))
+func loadNationalFormat(md *metadata.PhoneMetadata, numberFormatElement *NumberFormatE, format *metadata.NumberFormat) error {
+	if err := setLeadingDigitsPatterns(numberFormatElement, format); err != nil {
+		return err
+	}
+	pattern, err := validateRE(numberFormatElement.Pattern, false)
+	if err != nil {
+		return err
+	}
+	format.Pattern = sp(pattern)
 	format.Format = sp(numberFormatElement.Format)
+	return nil
 }
 
 func getDomesticCarrierCodeFormattingRule(carrierCodeFormattingRule string, nationalPrefix string) string {
@@ -239,10 +250,13 @@ func getNationalPrefixFormattingRule(nationalPrefixFormattingRule string, nation
  */
 // @VisibleForTesting
 func loadAvailableFormats(md *metadata.PhoneMetadata, element *TerritoryE, nationalPrefix string,
-	nationalPrefixFormattingRule string, nationalPrefixOptionalWhenFormatting bool) {
+	nationalPrefixFormattingRule string, nationalPrefixOptionalWhenFormatting bool) error {
 	carrierCodeFormattingRule := ""
 	if element.CarrierCodeFormattingRule != "" {
-		carrierCodeFormattingRule = validateRE(getDomesticCarrierCodeFormattingRule(element.CarrierCodeFormattingRule, nationalPrefix), false)
+		carrierCodeFormattingRule = getDomesticCarrierCodeFormattingRule(element.CarrierCodeFormattingRule, nationalPrefix)
+		if err := validREs(false, &carrierCodeFormattingRule); err != nil {
+			return err
+		}
 	}
 	numberFormatElements := element.AvailableFormats
 	hasExplicitIntlFormatDefined := false
@@ -265,14 +279,24 @@ func loadAvailableFormats(md *metadata.PhoneMetadata, element *TerritoryE, natio
 			}
 
 			if numberFormatElement.CarrierCodeFormattingRule != "" {
-				format.DomesticCarrierCodeFormattingRule = sp(validateRE(getDomesticCarrierCodeFormattingRule(numberFormatElement.CarrierCodeFormattingRule, nationalPrefix), false))
+				rule := getDomesticCarrierCodeFormattingRule(numberFormatElement.CarrierCodeFormattingRule, nationalPrefix)
+				if err := validREs(false, &rule); err != nil {
+					return err
+				}
+				format.DomesticCarrierCodeFormattingRule = sp(rule)
 			} else if carrierCodeFormattingRule != "" {
 				format.DomesticCarrierCodeFormattingRule = sp(carrierCodeFormattingRule)
 			}
-			loadNationalFormat(md, &numberFormatElement, &format)
+			if err := loadNationalFormat(md, &numberFormatElement, &format); err != nil {
+				return err
+			}
 			md.NumberFormat = append(md.NumberFormat, &format)
 
-			if loadInternationalFormat(md, &numberFormatElement, &format) {
+			hasIntlFormat, err := loadInternationalFormat(md, &numberFormatElement, &format)
+			if err != nil {
+				return err
+			}
+			if hasIntlFormat {
 				hasExplicitIntlFormatDefined = true
 			}
 		}
@@ -284,6 +308,7 @@ func loadAvailableFormats(md *metadata.PhoneMetadata, element *TerritoryE, natio
 			md.IntlNumberFormat = nil
 		}
 	}
+	return nil
 }
 
 /**
@@ -314,9 +339,9 @@ func arePossibleLengthsEqual(possibleLengths map[int32]bool, desc *metadata.Phon
  *     [min-max] notation, inclusive. For example, [3-5],7,9,[11-14] should be parsed to
  *     3,4,5,7,9,11,12,13,14.
  */
-func parsePossibleLengthStringToSet(possibleLengthString string) map[int32]bool {
+func parsePossibleLengthStringToSet(possibleLengthString string) (map[int32]bool, error) {
 	if possibleLengthString == "" {
-		panic("Empty possibleLength string found.")
+		return nil, fmt.Errorf("empty possibleLength string found")
 	}
 	lengths := strings.Split(possibleLengthString, ",")
 	lengthSet := make(map[int32]bool)
@@ -324,34 +349,43 @@ func parsePossibleLengthStringToSet(possibleLengthString string) map[int32]bool
 	for i := 0; i < len(lengths); i++ {
 		lengthSubstring := lengths[i]
 		if lengthSubstring == "" {
-			panic("Leading, trailing or adjacent commas in possible length string %s, these should only separate numbers or ranges.")
+			return nil, fmt.Errorf("leading, trailing or adjacent commas in possible length string %s, these should only separate numbers or ranges", possibleLengthString)
 		} else if lengthSubstring[0] == '[' {
 			if lengthSubstring[len(lengthSubstring)-1] != ']' {
-				panic(fmt.Sprintf("Missing end of range character in possible length string %s.", possibleLengthString))
+				return nil, fmt.Errorf("missing end of range character in possible length string %s", possibleLengthString)
 			}
 			// Strip the leading and trailing [], and split on the -.
 			minMax := strings.Split(lengthSubstring[1:len(lengthSubstring)-1], "-")
 			if len(minMax) != 2 {
-				panic(fmt.Sprintf("Ranges must have exactly one - character: missing for %s.", possibleLengthString))
+				return nil, fmt.Errorf("ranges must have exactly one - character: missing for %s", possibleLengthString)
+			}
+			min, err := strconv.Atoi(minMax[0])
+			if err != nil {
+				return nil, fmt.Errorf("invalid possible length string %s: %w", possibleLengthString, err)
+			}
+			max, err := strconv.Atoi(minMax[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid possible length string %s: %w", possibleLengthString, err)
 			}
-			min, _ := strconv.Atoi(minMax[0])
-			max, _ := strconv.Atoi(minMax[1])
 
 			// We don't even accept [6-7] since we prefer the shorter 6,7 variant; for a range to be in
 			// use the hyphen needs to replace at least one digit.
 			if max-min < 2 {
-				panic(fmt.Sprintf("The first number in a range should be two or more digits lower than the second. Culprit possibleLength string: %s", possibleLengthString))
+				return nil, fmt.Errorf("the first number in a range should be two or more digits lower than the second. Culprit possibleLength string: %s", possibleLengthString)
 			}
 
 			for j := min; j <= max; j++ {
 				lengthSet[int32(j)] = true
 			}
 		} else {
-			length, _ := strconv.Atoi(lengthSubstring)
+			length, err := strconv.Atoi(lengthSubstring)
+			if err != nil {
+				return nil, fmt.Errorf("invalid possible length string %s: %w", possibleLengthString, err)
+			}
 			lengthSet[int32(length)] = true
 		}
 	}
-	return lengthSet
+	return lengthSet, nil
 }
 
 /**
@@ -363,7 +397,7 @@ func parsePossibleLengthStringToSet(possibleLengthString string) map[int32]bool
  * @param localOnlyLengths  a set to which to add possible lengths of phone numbers only diallable
  *     locally (e.g. within a province)
  */
-func populatePossibleLengthSets(data []*PhoneNumberDescE, lengths map[int32]bool, localOnlyLengths map[int32]bool) {
+func populatePossibleLengthSets(data []*PhoneNumberDescE, lengths map[int32]bool, localOnlyLengths map[int32]bool) error {
 	for i := 0; i < len(data); i++ {
 		desc := data[i]
 		if desc == nil || desc.PossibleLengths == nil {
@@ -376,9 +410,15 @@ func populatePossibleLengthSets(data []*PhoneNumberDescE, lengths map[int32]bool
 		// We don't add to the phone metadata yet, since we want to sort length elements found under
 		// different nodes first, make sure there are no duplicates between them and that the
 		// localOnly lengths don't overlap with the others.
-		thisElementLengths := parsePossibleLengthStringToSet(nationalLengths)
+		thisElementLengths, err := parsePossibleLengthStringToSet(nationalLengths)
+		if err != nil {
+			return err
+		}
 		if element.LocalOnly != "" {
-			thisElementLocalOnlyLengths := parsePossibleLengthStringToSet(element.LocalOnly)
+			thisElementLocalOnlyLengths, err := parsePossibleLengthStringToSet(element.LocalOnly)
+			if err != nil {
+				return err
+			}
 
 			// intersect our two maps
 			intersection := make(map[int32]bool)
@@ -389,7 +429,7 @@ func populatePossibleLengthSets(data []*PhoneNumberDescE, lengths map[int32]bool
 			}
 
 			if len(intersection) != 0 {
-				panic(fmt.Sprintf("Possible length(s) found specified as a normal and local-only length: %v", intersection))
+				return fmt.Errorf("possible length(s) found specified as a normal and local-only length: %v", intersection)
 			}
 
 			// We check again when we set these lengths on the metadata itself in setPossibleLengths
@@ -407,6 +447,7 @@ func populatePossibleLengthSets(data []*PhoneNumberDescE, lengths map[int32]bool
 			lengths[k] = true
 		}
 	}
+	return nil
 }
 
 /**
@@ -428,14 +469,14 @@ func populatePossibleLengthSets(data []*PhoneNumberDescE, lengths map[int32]bool
  * @return  complete description of that phone number type
  */
 // @VisibleForTesting
-func processPhoneNumberDescElement(parentDesc *metadata.PhoneNumberDesc, element *PhoneNumberDescE) *metadata.PhoneNumberDesc {
+func processPhoneNumberDescElement(parentDesc *metadata.PhoneNumberDesc, element *PhoneNumberDescE) (*metadata.PhoneNumberDesc, error) {
 	numberDesc := metadata.PhoneNumberDesc{}
 	if element == nil {
 		// -1 will never match a possible phone number length, so is safe to use to ensure this
 		// never matches. We don't leave it empty, since for compression reasons, we use the empty
 		// list to mean that the generalDesc possible lengths apply.
 		numberDesc.PossibleLength = []int32{-1}
-		return &numberDesc
+		return &numberDesc, nil
 	}
 	if parentDesc != nil {
 		// New way of handling possible number lengths. We don't do this for the general
@@ -444,21 +485,26 @@ func processPhoneNumberDescElement(parentDesc *metadata.PhoneNumberDesc, element
 		// setPossibleLengthsGeneralDesc).
 		lengths := make(map[int32]bool)
 		localOnlyLengths := make(map[int32]bool)
-		populatePossibleLengthSets([]*PhoneNumberDescE{element}, lengths, localOnlyLengths)
-		setPossibleLengths(lengths, localOnlyLengths, parentDesc, &numberDesc)
+		if err := populatePossibleLengthSets([]*PhoneNumberDescE{element}, lengths, localOnlyLengths); err != nil {
+			return nil, err
+		}
+		if err := setPossibleLengths(lengths, localOnlyLengths, parentDesc, &numberDesc); err != nil {
+			return nil, err
+		}
 	}
 
-	validPattern := element.NationalNumberPattern
-	if validPattern != "" {
-		numberDesc.NationalNumberPattern = sp(validateRE(validPattern, true))
+	validPattern, err := validateRE(element.NationalNumberPattern, true)
+	if err != nil {
+		return nil, err
 	}
+	numberDesc.NationalNumberPattern = sp(validPattern)
 
 	exampleNumber := element.ExampleNumber
 	if exampleNumber != "" {
 		numberDesc.ExampleNumber = sp(exampleNumber)
 	}
 
-	return &numberDesc
+	return &numberDesc, nil
 }
 
 /**
@@ -469,7 +515,7 @@ func processPhoneNumberDescElement(parentDesc *metadata.PhoneNumberDesc, element
  * @param parentDesc  the "general description" element or null if desc is the generalDesc itself
  * @param desc  the PhoneNumberDesc object that we are going to set lengths for
  */
-func setPossibleLengths(lengths map[int32]bool, localOnlyLengths map[int32]bool, parentDesc *metadata.PhoneNumberDesc, desc *metadata.PhoneNumberDesc) {
+func setPossibleLengths(lengths map[int32]bool, localOnlyLengths map[int32]bool, parentDesc *metadata.PhoneNumberDesc, desc *metadata.PhoneNumberDesc) error {
 	// We clear these fields since the metadata tends to inherit from the parent element for other
 	// fields (via a mergeFrom).
 	desc.PossibleLength = nil
@@ -486,7 +532,7 @@ func setPossibleLengths(lengths map[int32]bool, localOnlyLengths map[int32]bool,
 				// the general description. We check this here even though the general description is
 				// derived from child elements because it is only derived from a subset, and we need to
 				// ensure *all* child elements have a valid possible length.
-				panic(fmt.Sprintf("Out-of-range possible length found (%d), parent lengths %v.", length, parentDesc.PossibleLength))
+				return fmt.Errorf("out-of-range possible length found (%d), parent lengths %v", length, parentDesc.PossibleLength)
 			}
 		}
 	}
@@ -502,7 +548,7 @@ func setPossibleLengths(lengths map[int32]bool, localOnlyLengths map[int32]bool,
 			if parentDesc == nil || parentDesc.HasPossibleLength(length) || parentDesc.HasPossibleLengthLocalOnly(length) {
 				desc.PossibleLengthLocalOnly = append(desc.PossibleLengthLocalOnly, length)
 			} else {
-				panic(fmt.Sprintf("Out-of-range local-only possible length found (%d), parent length %v.", length, parentDesc.PossibleLengthLocalOnly))
+				return fmt.Errorf("out-of-range local-only possible length found (%d), parent length %v", length, parentDesc.PossibleLengthLocalOnly)
 			}
 		}
 	}
@@ -510,12 +556,13 @@ func setPossibleLengths(lengths map[int32]bool, localOnlyLengths map[int32]bool,
 	// Need to sort both lists, possible lengths need to be ordered
 	sort.Slice(desc.PossibleLength, func(i, j int) bool { return desc.PossibleLength[i] < desc.PossibleLength[j] })
 	sort.Slice(desc.PossibleLengthLocalOnly, func(i, j int) bool { return desc.PossibleLengthLocalOnly[i] < desc.PossibleLengthLocalOnly[j] })
+	return nil
 }
 
 /**
  * Sets possible lengths in the general description, derived from certain child elements.
  */
-func setPossibleLengthsGeneralDesc(generalDesc *metadata.PhoneNumberDesc, metadataId string, data *TerritoryE, isShortNumberMetadata bool) {
+func setPossibleLengthsGeneralDesc(generalDesc *metadata.PhoneNumberDesc, metadataId string, data *TerritoryE, isShortNumberMetadata bool) error {
 	lengths := make(map[int32]bool)
 	localOnlyLengths := make(map[int32]bool)
 
@@ -524,12 +571,14 @@ func setPossibleLengthsGeneralDesc(generalDesc *metadata.PhoneNumberDesc, metada
 	// (However, for e.g. formatting metadata in PhoneNumberAlternateFormats, no PhoneNumberDesc
 	// elements are present).
 	generalDescNode := data.GeneralDesc
-	populatePossibleLengthSets([]*PhoneNumberDescE{generalDescNode}, lengths, localOnlyLengths)
+	if err := populatePossibleLengthSets([]*PhoneNumberDescE{generalDescNode}, lengths, localOnlyLengths); err != nil {
+		return err
+	}
 
 	if len(lengths) != 0 || len(localOnlyLengths) != 0 {
 		// We shouldn't have anything specified at the "general desc" level: we are going to
 		// calculate this ourselves from child elements.
-		panic(fmt.Sprintf("Found possible lengths specified at general desc: this should be derived from child elements. Affected country: %s", metadataId))
+		return fmt.Errorf("found possible lengths specified at general desc: this should be derived from child elements. Affected country: %s", metadataId)
 	}
 
 	if !isShortNumberMetadata {
@@ -538,48 +587,83 @@ func setPossibleLengthsGeneralDesc(generalDesc *metadata.PhoneNumberDesc, metada
 		trimmedDescs := []*PhoneNumberDescE{data.GeneralDesc, data.FixedLine, data.Mobile, data.Pager,
 			data.TollFree, data.PremiumRate, data.SharedCost, data.PersonalNumber, data.VOIP, data.UAN, data.VoiceMail, data.StandardRate,
 			data.ShortCode, data.Emergency, data.CarrierSpecific}
-		populatePossibleLengthSets(trimmedDescs, lengths, localOnlyLengths)
+		if err := populatePossibleLengthSets(trimmedDescs, lengths, localOnlyLengths); err != nil {
+			return err
+		}
 	} else {
-		populatePossibleLengthSets([]*PhoneNumberDescE{data.ShortCode}, lengths, localOnlyLengths)
+		if err := populatePossibleLengthSets([]*PhoneNumberDescE{data.ShortCode}, lengths, localOnlyLengths); err != nil {
+			return err
+		}
 		if len(localOnlyLengths) > 0 {
-			panic(fmt.Errorf("found local-only lengths in short-number metadata"))
+			return fmt.Errorf("found local-only lengths in short-number metadata")
 		}
 	}
-	setPossibleLengths(lengths, localOnlyLengths, nil, generalDesc)
+	return setPossibleLengths(lengths, localOnlyLengths, nil, generalDesc)
 }
 
-func loadCountryMetadata(regionCode string, element *TerritoryE, isShortNumberMetadata bool, isAlternateFormatsMetadata bool) *metadata.PhoneMetadata {
+func loadCountryMetadata(regionCode string, element *TerritoryE, isShortNumberMetadata bool, isAlternateFormatsMetadata bool) (*metadata.PhoneMetadata, error) {
 	nationalPrefix := element.NationalPrefix
-	md := loadTerritoryTagMetadata(regionCode, element, nationalPrefix)
+	md, err := loadTerritoryTagMetadata(regionCode, element, nationalPrefix)
+	if err != nil {
+		return nil, err
+	}
 	nationalPrefixFormattingRule := getNationalPrefixFormattingRule(element.NationalPrefixFormattingRule, nationalPrefix)
-	loadAvailableFormats(md, element, nationalPrefix, nationalPrefixFormattingRule, element.NationalPrefixOptionalWhenFormatting)
+	if err := loadAvailableFormats(md, element, nationalPrefix, nationalPrefixFormattingRule, element.NationalPrefixOptionalWhenFormatting); err != nil {
+		return nil, err
+	}
 
 	if !isAlternateFormatsMetadata {
 		// The alternate formats metadata does not need most of the patterns to be set.
-		setRelevantDescPatterns(md, element, isShortNumberMetadata)
+		if err := setRelevantDescPatterns(md, element, isShortNumberMetadata); err != nil {
+			return nil, err
+		}
 	}
-	return md
+	return md, nil
 }
 
-func setRelevantDescPatterns(md *metadata.PhoneMetadata, element *TerritoryE, isShortNumberMetadata bool) {
-	generalDesc := processPhoneNumberDescElement(nil, element.GeneralDesc)
+func setRelevantDescPatterns(md *metadata.PhoneMetadata, element *TerritoryE, isShortNumberMetadata bool) error {
+	generalDesc, err := processPhoneNumberDescElement(nil, element.GeneralDesc)
+	if err != nil {
+		return err
+	}
 
 	// Calculate the possible lengths for the general description. This will be based on the
 	// possible lengths of the child elements.
-	setPossibleLengthsGeneralDesc(generalDesc, md.GetId(), element, isShortNumberMetadata)
+	if err := setPossibleLengthsGeneralDesc(generalDesc, md.GetId(), element, isShortNumberMetadata); err != nil {
+		return err
+	}
 	md.GeneralDesc = generalDesc
 
+	type descElement struct {
+		desc    **metadata.PhoneNumberDesc
+		element *PhoneNumberDescE
+	}
+	processDescs := func(descs ...descElement) error {
+		for _, d := range descs {
+			desc, err := processPhoneNumberDescElement(generalDesc, d.element)
+			if err != nil {
+				return err
+			}
+			*d.desc = desc
+		}
+		return nil
+	}
+
 	if !isShortNumberMetadata {
 		// Set fields used by regular length phone numbers.
-		md.FixedLine = processPhoneNumberDescElement(generalDesc, element.FixedLine)
-		md.Mobile = processPhoneNumberDescElement(generalDesc, element.Mobile)
-		md.SharedCost = processPhoneNumberDescElement(generalDesc, element.SharedCost)
-		md.Voip = processPhoneNumberDescElement(generalDesc, element.VOIP)
-		md.PersonalNumber = processPhoneNumberDescElement(generalDesc, element.PersonalNumber)
-		md.Pager = processPhoneNumberDescElement(generalDesc, element.Pager)
-		md.Uan = processPhoneNumberDescElement(generalDesc, element.UAN)
-		md.Voicemail = processPhoneNumberDescElement(generalDesc, element.VoiceMail)
-		md.NoInternationalDialling = processPhoneNumberDescElement(generalDesc, element.NoInternationalDialing)
+		if err := processDescs(
+			descElement{&md.FixedLine, element.FixedLine},
+			descElement{&md.Mobile, element.Mobile},
+			descElement{&md.SharedCost, element.SharedCost},
+			descElement{&md.Voip, element.VOIP},
+			descElement{&md.PersonalNumber, element.PersonalNumber},
+			descElement{&md.Pager, element.Pager},
+			descElement{&md.Uan, element.UAN},
+			descElement{&md.Voicemail, element.VoiceMail},
+			descElement{&md.NoInternationalDialling, element.NoInternationalDialing},
+		); err != nil {
+			return err
+		}
 
 		// Use the nil-safe getters (which return "" for an absent pattern) to
 		// match upstream: a region may legitimately have no mobile or fixed-line
@@ -589,18 +673,22 @@ func setRelevantDescPatterns(md *metadata.PhoneMetadata, element *TerritoryE, is
 			md.SameMobileAndFixedLinePattern = bp(mobileAndFixedAreSame)
 		}
 
-		md.TollFree = processPhoneNumberDescElement(generalDesc, element.TollFree)
-		md.PremiumRate = processPhoneNumberDescElement(generalDesc, element.PremiumRate)
-	} else {
-		// Set fields used by short numbers.
-		md.StandardRate = processPhoneNumberDescElement(generalDesc, element.StandardRate)
-		md.ShortCode = processPhoneNumberDescElement(generalDesc, element.ShortCode)
-		md.CarrierSpecific = processPhoneNumberDescElement(generalDesc, element.CarrierSpecific)
-		md.Emergency = processPhoneNumberDescElement(generalDesc, element.Emergency)
-		md.TollFree = processPhoneNumberDescElement(generalDesc, element.TollFree)
-		md.PremiumRate = processPhoneNumberDescElement(generalDesc, element.PremiumRate)
-		md.SmsServices = processPhoneNumberDescElement(generalDesc, element.SmsServices)
-	}
+		return processDescs(
+			descElement{&md.TollFree, element.TollFree},
+			descElement{&md.PremiumRate, element.PremiumRate},
+		)
+	}
+
+	// Set fields used by short numbers.
+	return processDescs(
+		descElement{&md.StandardRate, element.StandardRate},
+		descElement{&md.ShortCode, element.ShortCode},
+		descElement{&md.CarrierSpecific, element.CarrierSpecific},
+		descElement{&md.Emergency, element.Emergency},
+		descElement{&md.TollFree, element.TollFree},
+		descElement{&md.PremiumRate, element.PremiumRate},
+		descElement{&md.SmsServices, element.SmsServices},
+	)
 }
 
 // <!ELEMENT phoneNumberMetadata (territories)>
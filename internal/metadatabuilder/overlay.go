@@ -0,0 +1,248 @@
+package metadatabuilder
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+
+	"github.com/nyaruka/phonenumbers/v2/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// ApplyOverlay patches a metadata collection with the territories in an overlay
+// document. The overlay uses the same XML as upstream's PhoneNumberMetadata.xml,
+// but a territory that already exists in base only needs the elements being
+// added:
+//
+//   - the nationalNumberPattern of each number type (and of generalDesc) is
+//     alternated with the base pattern, so the type matches both
+//   - possibleLengths are added to those of the type and of the general
+//     description
+//   - an exampleNumber replaces the base one
+//   - availableFormats are placed ahead of the base formats, so they take
+//     precedence for the numbers they match. They are expanded using the
+//     overlay territory's nationalPrefix and nationalPrefixFormattingRule
+//     attributes, defaulting to the base national prefix.
+//
+// Other territory attributes are ignored for existing territories. Territories
+// not in base are built in full, exactly as BuildPhoneMetadataCollection would.
+// Base is not modified.
+func ApplyOverlay(base *metadata.PhoneMetadataCollection, overlayXML []byte) (*metadata.PhoneMetadataCollection, error) {
+	doc := &PhoneNumberMetadataE{}
+	if err := xml.Unmarshal(overlayXML, doc); err != nil {
+		return nil, fmt.Errorf("error unmarshalling XML: %w", err)
+	}
+
+	collection := proto.Clone(base).(*metadata.PhoneMetadataCollection)
+	for i := range doc.Territories {
+		territoryElement := &doc.Territories[i]
+		md := findTerritory(collection, territoryElement)
+		if md == nil {
+			if territoryElement.CountryCode == 0 {
+				return nil, fmt.Errorf("overlay territory %s is not in base metadata and has no country code", territoryElement.ID)
+			}
+			md, err := loadCountryMetadata(territoryElement.ID, territoryElement, false, false)
+			if err != nil {
+				return nil, err
+			}
+			collection.Metadata = append(collection.Metadata, md)
+			continue
+		}
+		if territoryElement.CountryCode != 0 && territoryElement.CountryCode != md.GetCountryCode() {
+			return nil, fmt.Errorf("overlay territory %s has country code %d, base metadata has %d", md.GetId(), territoryElement.CountryCode, md.GetCountryCode())
+		}
+
+		if err := overlayAvailableFormats(md, territoryElement); err != nil {
+			return nil, err
+		}
+		if err := overlayDescPatterns(md, territoryElement); err != nil {
+			return nil, err
+		}
+	}
+	return collection, nil
+}
+
+// findTerritory returns the territory in collection the overlay element
+// patches. Non-geographical entities all share the id "001", so those are
+// matched on country code too.
+func findTerritory(collection *metadata.PhoneMetadataCollection, element *TerritoryE) *metadata.PhoneMetadata {
+	for _, md := range collection.Metadata {
+		if md.GetId() != element.ID {
+			continue
+		}
+		if element.ID == "001" && element.CountryCode != md.GetCountryCode() {
+			continue
+		}
+		return md
+	}
+	return nil
+}
+
+func overlayAvailableFormats(md *metadata.PhoneMetadata, element *TerritoryE) error {
+	if len(element.AvailableFormats) == 0 {
+		return nil
+	}
+
+	nationalPrefix := element.NationalPrefix
+	if nationalPrefix == "" {
+		nationalPrefix = md.GetNationalPrefix()
+	}
+	nationalPrefixFormattingRule := getNationalPrefixFormattingRule(element.NationalPrefixFormattingRule, nationalPrefix)
+
+	overlay := &metadata.PhoneMetadata{Id: md.Id}
+	if err := loadAvailableFormats(overlay, element, nationalPrefix, nationalPrefixFormattingRule, element.NationalPrefixOptionalWhenFormatting); err != nil {
+		return err
+	}
+
+	// An empty intlNumberFormat means the national formats are used
+	// internationally too, so only spell out both lists if either side has
+	// explicit international formats.
+	if len(overlay.IntlNumberFormat) > 0 || len(md.IntlNumberFormat) > 0 {
+		overlayIntl := overlay.IntlNumberFormat
+		if len(overlayIntl) == 0 {
+			overlayIntl = cloneFormats(overlay.NumberFormat)
+		}
+		baseIntl := md.IntlNumberFormat
+		if len(baseIntl) == 0 {
+			baseIntl = cloneFormats(md.NumberFormat)
+		}
+		md.IntlNumberFormat = append(overlayIntl, baseIntl...)
+	}
+	md.NumberFormat = append(overlay.NumberFormat, md.NumberFormat...)
+	return nil
+}
+
+func cloneFormats(formats []*metadata.NumberFormat) []*metadata.NumberFormat {
+	clones := make([]*metadata.NumberFormat, len(formats))
+	for i, f := range formats {
+		clones[i] = proto.Clone(f).(*metadata.NumberFormat)
+	}
+	return clones
+}
+
+func overlayDescPatterns(md *metadata.PhoneMetadata, element *TerritoryE) error {
+	if md.GeneralDesc == nil {
+		md.GeneralDesc = &metadata.PhoneNumberDesc{}
+	}
+	generalDesc := md.GeneralDesc
+
+	descs := []struct {
+		desc    **metadata.PhoneNumberDesc
+		element *PhoneNumberDescE
+	}{
+		{&md.FixedLine, element.FixedLine},
+		{&md.Mobile, element.Mobile},
+		{&md.TollFree, element.TollFree},
+		{&md.PremiumRate, element.PremiumRate},
+		{&md.SharedCost, element.SharedCost},
+		{&md.PersonalNumber, element.PersonalNumber},
+		{&md.Voip, element.VOIP},
+		{&md.Pager, element.Pager},
+		{&md.Uan, element.UAN},
+		{&md.Voicemail, element.VoiceMail},
+		{&md.NoInternationalDialling, element.NoInternationalDialing},
+	}
+
+	// A type with no possible lengths of its own inherits those of the general
+	// description, which is about to grow, so pin them first.
+	for _, d := range descs {
+		if *d.desc != nil && len((*d.desc).PossibleLength) == 0 {
+			(*d.desc).PossibleLength = append([]int32(nil), generalDesc.PossibleLength...)
+		}
+	}
+
+	if element.GeneralDesc != nil && element.GeneralDesc.NationalNumberPattern != "" {
+		pattern, err := alternatePattern(generalDesc.NationalNumberPattern, element.GeneralDesc.NationalNumberPattern)
+		if err != nil {
+			return err
+		}
+		generalDesc.NationalNumberPattern = pattern
+	}
+
+	for _, d := range descs {
+		if d.element == nil {
+			continue
+		}
+		if *d.desc == nil || isAbsentDesc(*d.desc) {
+			*d.desc = &metadata.PhoneNumberDesc{}
+		}
+		desc := *d.desc
+
+		lengths := make(map[int32]bool)
+		localOnlyLengths := make(map[int32]bool)
+		if err := populatePossibleLengthSets([]*PhoneNumberDescE{d.element}, lengths, localOnlyLengths); err != nil {
+			return err
+		}
+
+		if d.element.NationalNumberPattern != "" {
+			pattern, err := alternatePattern(desc.NationalNumberPattern, d.element.NationalNumberPattern)
+			if err != nil {
+				return err
+			}
+			desc.NationalNumberPattern = pattern
+			// the pattern is valid, so this can't fail
+			generalDesc.NationalNumberPattern, _ = alternatePattern(generalDesc.NationalNumberPattern, d.element.NationalNumberPattern)
+		}
+		if d.element.ExampleNumber != "" {
+			desc.ExampleNumber = sp(d.element.ExampleNumber)
+		}
+		desc.PossibleLength = addLengths(desc.PossibleLength, lengths)
+		desc.PossibleLengthLocalOnly = addLengths(desc.PossibleLengthLocalOnly, localOnlyLengths)
+		generalDesc.PossibleLength = addLengths(generalDesc.PossibleLength, lengths)
+		generalDesc.PossibleLengthLocalOnly = addLengths(generalDesc.PossibleLengthLocalOnly, localOnlyLengths)
+	}
+
+	// as in setPossibleLengths, a general description length can't also be local-only
+	var localOnly []int32
+	for _, length := range generalDesc.PossibleLengthLocalOnly {
+		if !generalDesc.HasPossibleLength(length) {
+			localOnly = append(localOnly, length)
+		}
+	}
+	generalDesc.PossibleLengthLocalOnly = localOnly
+
+	mobileAndFixedAreSame := md.GetMobile().GetNationalNumberPattern() == md.GetFixedLine().GetNationalNumberPattern()
+	if md.GetSameMobileAndFixedLinePattern() != mobileAndFixedAreSame {
+		md.SameMobileAndFixedLinePattern = bp(mobileAndFixedAreSame)
+	}
+	return nil
+}
+
+// isAbsentDesc reports whether desc is the placeholder processPhoneNumberDescElement
+// emits for a number type the territory doesn't have.
+func isAbsentDesc(desc *metadata.PhoneNumberDesc) bool {
+	return len(desc.PossibleLength) == 1 && desc.PossibleLength[0] == -1
+}
+
+// alternatePattern returns a pattern matching either the base pattern or the
+// (validated) overlay pattern.
+func alternatePattern(base *string, overlay string) (*string, error) {
+	overlay, err := validateRE(overlay, true)
+	if err != nil {
+		return nil, err
+	}
+	if base == nil || *base == "" {
+		return sp(overlay), nil
+	}
+	return sp("(?:" + *base + ")|(?:" + overlay + ")"), nil
+}
+
+// addLengths returns the sorted union of existing and lengths.
+func addLengths(existing []int32, lengths map[int32]bool) []int32 {
+	if len(lengths) == 0 {
+		return existing
+	}
+	union := make(map[int32]bool, len(existing)+len(lengths))
+	for _, length := range existing {
+		union[length] = true
+	}
+	for length := range lengths {
+		union[length] = true
+	}
+	merged := make([]int32, 0, len(union))
+	for length := range union {
+		merged = append(merged, length)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i] < merged[j] })
+	return merged
+}
@@ -0,0 +1,172 @@
+package phonenumbers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONNumber wraps a PhoneNumber so that it marshals to text and JSON as a
+// formatted number, rather than as the fields of the generated struct, and
+// unmarshals again with Parse. Numbers are written in E164 format, with any
+// extension appended as in RFC3966 (e.g. "+16502530000;ext=123"), or in
+// RFC3966 format if Format is set to RFC3966. A nil Number is written as an
+// empty string, or as null in JSON.
+//
+// For API responses, Verbose instead marshals JSON as an object, which also
+// has the number's formatted variants, type and region:
+//
+//	{
+//	  "number": "+16502530000",
+//	  "e164": "+16502530000",
+//	  "international": "+1 650-253-0000",
+//	  "national": "(650) 253-0000",
+//	  "rfc3966": "tel:+1-650-253-0000",
+//	  "type": "FIXED_LINE_OR_MOBILE",
+//	  "region": "US",
+//	  "valid": true
+//	}
+//
+// Both forms unmarshal.
+type JSONNumber struct {
+	Number *PhoneNumber
+
+	// Format is the format numbers are written in, either E164 or RFC3966.
+	Format PhoneNumberFormat
+
+	// Verbose marshals JSON as an object describing the number.
+	Verbose bool
+
+	// DefaultRegion is the region used to parse numbers without a country
+	// calling code. If empty, such numbers fail to unmarshal.
+	DefaultRegion string
+
+	// Util is the Util numbers are parsed and formatted with. If nil, the
+	// default Util is used.
+	Util *Util
+}
+
+func (n *JSONNumber) util() *Util {
+	if n.Util != nil {
+		return n.Util
+	}
+	return DefaultUtil()
+}
+
+// numberTypeNames are the upstream names of the number types, which verbose
+// JSON uses for the type.
+var numberTypeNames = [...]string{
+	FIXED_LINE:           "FIXED_LINE",
+	MOBILE:               "MOBILE",
+	FIXED_LINE_OR_MOBILE: "FIXED_LINE_OR_MOBILE",
+	TOLL_FREE:            "TOLL_FREE",
+	PREMIUM_RATE:         "PREMIUM_RATE",
+	SHARED_COST:          "SHARED_COST",
+	VOIP:                 "VOIP",
+	PERSONAL_NUMBER:      "PERSONAL_NUMBER",
+	PAGER:                "PAGER",
+	UAN:                  "UAN",
+	VOICEMAIL:            "VOICEMAIL",
+	UNKNOWN:              "UNKNOWN",
+}
+
+// numberTypeName returns the upstream name of t, e.g. "TOLL_FREE".
+func numberTypeName(t PhoneNumberType) string {
+	if t < 0 || int(t) >= len(numberTypeNames) {
+		return "UNKNOWN"
+	}
+	return numberTypeNames[t]
+}
+
+// verboseNumber is the JSON object a Verbose JSONNumber marshals to.
+type verboseNumber struct {
+	Number        string `json:"number"`
+	E164          string `json:"e164"`
+	International string `json:"international"`
+	National      string `json:"national"`
+	RFC3966       string `json:"rfc3966"`
+	Extension     string `json:"extension,omitempty"`
+	Type          string `json:"type"`
+	Region        string `json:"region,omitempty"`
+	Valid         bool   `json:"valid"`
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (n JSONNumber) MarshalText() ([]byte, error) {
+	if n.Number == nil {
+		return []byte{}, nil
+	}
+	switch n.Format {
+	case E164:
+		return []byte(n.util().formatE164WithExtension(n.Number)), nil
+	case RFC3966:
+		return []byte(n.util().Format(n.Number, RFC3966)), nil
+	}
+	return nil, fmt.Errorf("unsupported format %d for marshalling phone number, must be E164 or RFC3966", n.Format)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (n *JSONNumber) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		n.Number = nil
+		return nil
+	}
+
+	number, err := n.util().Parse(string(text), n.DefaultRegion)
+	if err != nil {
+		return err
+	}
+	n.Number = number
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n JSONNumber) MarshalJSON() ([]byte, error) {
+	if n.Number == nil {
+		return []byte("null"), nil
+	}
+
+	text, err := n.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	if !n.Verbose {
+		return json.Marshal(string(text))
+	}
+
+	u := n.util()
+	return json.Marshal(&verboseNumber{
+		Number:        string(text),
+		E164:          u.Format(n.Number, E164),
+		International: u.Format(n.Number, INTERNATIONAL),
+		National:      u.Format(n.Number, NATIONAL),
+		RFC3966:       u.Format(n.Number, RFC3966),
+		Extension:     n.Number.GetExtension(),
+		Type:          numberTypeName(u.GetNumberType(n.Number)),
+		Region:        u.GetRegionCodeForNumber(n.Number),
+		Valid:         u.IsValidNumber(n.Number),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts both the string and
+// the verbose object forms.
+func (n *JSONNumber) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	if bytes.Equal(data, []byte("null")) {
+		n.Number = nil
+		return nil
+	}
+
+	var text string
+	if len(data) > 0 && data[0] == '{' {
+		var v verboseNumber
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		text = v.Number
+	} else if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return n.UnmarshalText([]byte(text))
+}
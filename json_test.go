@@ -0,0 +1,96 @@
+package phonenumbers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nyaruka/phonenumbers/v2/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestJSONNumber(t *testing.T) {
+	useTestMetadata(t)
+
+	us := usNumber()
+	withExtension := &PhoneNumber{CountryCode: proto.Int32(1), NationalNumber: proto.Uint64(6502530000), Extension: proto.String("123")}
+
+	// text round-trips in both formats
+	for _, format := range []PhoneNumberFormat{E164, RFC3966} {
+		for _, num := range []*PhoneNumber{us, withExtension, itNumber()} {
+			text, err := JSONNumber{Number: num, Format: format}.MarshalText()
+			assert.NoError(t, err)
+
+			var n JSONNumber
+			assert.NoError(t, n.UnmarshalText(text))
+			assert.True(t, proto.Equal(num, n.Number), "round trip mismatch for %s", text)
+		}
+	}
+
+	text, err := JSONNumber{Number: withExtension}.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "+16502530000;ext=123", string(text))
+	text, err = JSONNumber{Number: withExtension, Format: RFC3966}.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "tel:+1-650-253-0000;ext=123", string(text))
+	_, err = JSONNumber{Number: us, Format: NATIONAL}.MarshalText()
+	assert.EqualError(t, err, "unsupported format 2 for marshalling phone number, must be E164 or RFC3966")
+
+	// JSON, as part of a larger document
+	type contact struct {
+		Name  string      `json:"name"`
+		Phone JSONNumber  `json:"phone"`
+		Fax   *JSONNumber `json:"fax"`
+	}
+	data, err := json.Marshal(contact{Name: "Bob", Phone: JSONNumber{Number: us}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name": "Bob", "phone": "+16502530000", "fax": null}`, string(data))
+
+	var c contact
+	assert.NoError(t, json.Unmarshal([]byte(`{"name": "Bob", "phone": "+1 650 253 0000", "fax": null}`), &c))
+	assert.True(t, proto.Equal(us, c.Phone.Number))
+	assert.Nil(t, c.Fax)
+
+	data, err = json.Marshal(JSONNumber{Number: withExtension, Verbose: true})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"number": "+16502530000;ext=123",
+		"e164": "+16502530000",
+		"international": "+1 650 253 0000 extn. 123",
+		"national": "650 253 0000 extn. 123",
+		"rfc3966": "tel:+1-650-253-0000;ext=123",
+		"extension": "123",
+		"type": "FIXED_LINE_OR_MOBILE",
+		"region": "US",
+		"valid": true
+	}`, string(data))
+
+	var n JSONNumber
+	assert.NoError(t, json.Unmarshal(data, &n))
+	assert.True(t, proto.Equal(withExtension, n.Number))
+
+	// numbers without a country calling code need a default region
+	n = JSONNumber{}
+	err = json.Unmarshal([]byte(`"650 253 0000"`), &n)
+	assert.ErrorIs(t, err, ErrInvalidCountryCode)
+	n = JSONNumber{DefaultRegion: regionCode.US}
+	assert.NoError(t, json.Unmarshal([]byte(`"650 253 0000"`), &n))
+	assert.True(t, proto.Equal(us, n.Number))
+
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &n))
+	assert.Nil(t, n.Number)
+	assert.Error(t, json.Unmarshal([]byte(`123`), &n))
+
+	// numbers can be parsed and formatted against other metadata
+	coll, ccToRegion := syntheticCollection()
+	mc, err := metadata.NewContainer(coll, ccToRegion)
+	require.NoError(t, err)
+	n = JSONNumber{DefaultRegion: "XX", Util: NewUtil(mc)}
+	assert.NoError(t, json.Unmarshal([]byte(`"1234567"`), &n))
+	assert.Equal(t, int32(999), n.Number.GetCountryCode())
+	n.Verbose = true
+	data, err = json.Marshal(n)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"type":"FIXED_LINE","region":"XX","valid":true`)
+}
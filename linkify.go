@@ -0,0 +1,255 @@
+package phonenumbers
+
+import (
+	"html"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LinkifyFormat is the kind of text Linkify works on.
+type LinkifyFormat int
+
+const (
+	// LINKIFY_TEXT takes plain text and returns HTML, with the text escaped
+	// and numbers wrapped in anchors.
+	LINKIFY_TEXT LinkifyFormat = iota
+	// LINKIFY_HTML takes HTML and returns it with numbers in its visible text,
+	// as found by FindNumbersInHTML, wrapped in anchors. The rest of the
+	// markup is left as it is.
+	LINKIFY_HTML
+	// LINKIFY_MARKDOWN takes Markdown and returns it with numbers replaced by
+	// Markdown links.
+	LINKIFY_MARKDOWN
+)
+
+// LinkifyOptions are the options for Linkify.
+type LinkifyOptions struct {
+	Format LinkifyFormat
+
+	// Leniency is the leniency numbers are found with, as for
+	// FindNumbersWithLeniency, or nil for VALID.
+	Leniency *Leniency
+
+	// Match are the options numbers are found with, as for
+	// FindNumbersWithLeniency.
+	Match MatchOptions
+
+	// Attributes are added to each anchor, e.g. "class", in order of name.
+	// Names which aren't valid HTML attribute names are skipped. Markdown
+	// links have no attributes, so they're ignored for Markdown.
+	Attributes map[string]string
+}
+
+// leniency returns the leniency numbers are found with.
+func (o LinkifyOptions) leniency() Leniency {
+	if o.Leniency == nil {
+		return VALID
+	}
+	return *o.Leniency
+}
+
+// markdownLinkPattern matches inline links and autolinks in Markdown.
+var markdownLinkPattern = regexp.MustCompile(`\[[^\]]*\]\([^)]*\)|<[A-Za-z][A-Za-z0-9+.-]*:[^<>\s]*>`)
+
+// markdownEscaper escapes the characters which would end the text of a
+// Markdown link.
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, `[`, `\[`, `]`, `\]`)
+
+// voidHTMLElements are the inline elements which have no end tag.
+var voidHTMLElements = map[string]bool{"wbr": true}
+
+// Linkify returns text with each number found in it linked to its tel: URI, formatted as RFC3966, so that it can be tapped to call on phones, e.g.
+// "Call 650 253 0000" becomes
+// `Call <a href="tel:+1-650-253-0000">650 253 0000</a>` for the region "US".
+//
+// Numbers which are already linked are left as they are, as links can't be
+// nested: those in or containing anchors with LINKIFY_HTML, and those
+// overlapping inline links or autolinks with LINKIFY_MARKDOWN.
+//
+// With LINKIFY_HTML, a number split across inline elements is linked if the
+// anchor can include the elements whole, as with "<b>650</b> 253 0000", and
+// otherwise left as it is.
+func (u *Util) Linkify(text, defaultRegion string, opts LinkifyOptions) string {
+	var sb strings.Builder
+	last := 0
+	leniency := opts.leniency()
+
+	switch opts.Format {
+	case LINKIFY_HTML:
+		visible := extractHTMLText(text)
+		tags := &htmlTagCursor{tags: visible.tags}
+		for m := range u.FindNumbersWithLeniency(visible.String(), defaultRegion, leniency, math.MaxInt, opts.Match) {
+			start, end := visible.sourceRange(m.Start(), m.End())
+			if tags.linked(start, end) {
+				continue
+			}
+			start, end, ok := tags.wholeElements(start, end)
+			if !ok || start < last {
+				continue
+			}
+			sb.WriteString(text[last:start])
+			writeAnchor(&sb, u.Format(m.Number(), RFC3966), text[start:end], opts.Attributes)
+			last = end
+		}
+		sb.WriteString(text[last:])
+
+	case LINKIFY_MARKDOWN:
+		links := markdownLinkPattern.FindAllStringIndex(text, -1)
+		for m := range u.FindNumbersWithLeniency(text, defaultRegion, leniency, math.MaxInt, opts.Match) {
+			if slices.ContainsFunc(links, func(l []int) bool { return m.Start() < l[1] && m.End() > l[0] }) {
+				continue
+			}
+			sb.WriteString(text[last:m.Start()])
+			sb.WriteString("[")
+			sb.WriteString(markdownEscaper.Replace(m.RawString()))
+			sb.WriteString("](")
+			sb.WriteString(u.Format(m.Number(), RFC3966))
+			sb.WriteString(")")
+			last = m.End()
+		}
+		sb.WriteString(text[last:])
+
+	default:
+		for m := range u.FindNumbersWithLeniency(text, defaultRegion, leniency, math.MaxInt, opts.Match) {
+			sb.WriteString(html.EscapeString(text[last:m.Start()]))
+			writeAnchor(&sb, u.Format(m.Number(), RFC3966), html.EscapeString(m.RawString()), opts.Attributes)
+			last = m.End()
+		}
+		sb.WriteString(html.EscapeString(text[last:]))
+	}
+
+	return sb.String()
+}
+
+// writeAnchor writes an anchor linking to href around the HTML content.
+func writeAnchor(sb *strings.Builder, href, content string, attributes map[string]string) {
+	sb.WriteString(`<a href="`)
+	sb.WriteString(html.EscapeString(href))
+	sb.WriteString(`"`)
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		if !isHTMLAttributeName(name) {
+			continue
+		}
+		sb.WriteString(" ")
+		sb.WriteString(name)
+		sb.WriteString(`="`)
+		sb.WriteString(html.EscapeString(attributes[name]))
+		sb.WriteString(`"`)
+	}
+	sb.WriteString(">")
+	sb.WriteString(content)
+	sb.WriteString("</a>")
+}
+
+// isHTMLAttributeName reports whether name is a valid HTML attribute name:
+// one or more characters other than controls, spaces, noncharacters and
+// `"'>/=`.
+func isHTMLAttributeName(name string) bool {
+	if name == "" || !utf8.ValidString(name) {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case unicode.IsControl(r), unicode.IsSpace(r), strings.ContainsRune(`"'>/=`, r):
+			return false
+		case r >= 0xFDD0 && r <= 0xFDEF, r&0xFFFE == 0xFFFE:
+			// noncharacters
+			return false
+		}
+	}
+	return true
+}
+
+// htmlTagCursor walks the tags of an htmlText alongside the numbers found in
+// its visible text. Numbers are found in order, so each tag is only passed
+// once, however many numbers there are.
+type htmlTagCursor struct {
+	tags []htmlTag
+
+	// next is the index of the first tag not yet passed.
+	next int
+
+	// anchors is the number of anchors open after the tags passed.
+	anchors int
+}
+
+// advance passes the tags which end at or before the document offset.
+func (c *htmlTagCursor) advance(offset int) {
+	for ; c.next < len(c.tags) && c.tags[c.next].end <= offset; c.next++ {
+		if tag := c.tags[c.next]; tag.name == "a" {
+			if !tag.isEnd {
+				c.anchors++
+			} else if c.anchors > 0 {
+				c.anchors--
+			}
+		}
+	}
+}
+
+// linked reports whether the range of the document from start to end is
+// inside an anchor or contains part of one.
+func (c *htmlTagCursor) linked(start, end int) bool {
+	c.advance(start)
+	if c.anchors > 0 {
+		return true
+	}
+	for _, tag := range c.tags[c.next:] {
+		if tag.start >= end {
+			break
+		}
+		if tag.name == "a" {
+			return true
+		}
+	}
+	return false
+}
+
+// wholeElements widens the range of the document from start to end so that
+// it contains both the start and end tags of each element it contains either
+// of, which is only possible when the missing tags are directly before or
+// after it. It reports false if they aren't.
+func (c *htmlTagCursor) wholeElements(start, end int) (int, int, bool) {
+	c.advance(start)
+
+	var unclosed, unopened []string
+	after := c.next
+	for ; after < len(c.tags) && c.tags[after].end <= end; after++ {
+		tag := c.tags[after]
+		if voidHTMLElements[tag.name] {
+			continue
+		}
+		if !tag.isEnd {
+			unclosed = append(unclosed, tag.name)
+		} else if len(unclosed) > 0 && unclosed[len(unclosed)-1] == tag.name {
+			unclosed = unclosed[:len(unclosed)-1]
+		} else {
+			unopened = append(unopened, tag.name)
+		}
+	}
+
+	// take in the start tags before the range, innermost first
+	before := c.next - 1
+	for _, name := range unopened {
+		if before < 0 || c.tags[before].end != start || c.tags[before].isEnd || c.tags[before].name != name {
+			return 0, 0, false
+		}
+		start = c.tags[before].start
+		before--
+	}
+
+	// and the end tags after it, again innermost first
+	for _, name := range slices.Backward(unclosed) {
+		if after >= len(c.tags) || c.tags[after].start != end || !c.tags[after].isEnd || c.tags[after].name != name {
+			return 0, 0, false
+		}
+		end = c.tags[after].end
+		after++
+	}
+
+	return start, end, true
+}
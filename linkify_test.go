@@ -0,0 +1,64 @@
+package phonenumbers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkify(t *testing.T) {
+	useTestMetadata(t)
+
+	// plain text is escaped
+	assert.Equal(t,
+		`Call <a href="tel:+1-650-253-0000">650 253 0000</a> &amp; ask for &lt;Bob&gt;`,
+		Linkify("Call 650 253 0000 & ask for <Bob>", regionCode.US, LinkifyOptions{}),
+	)
+	assert.Equal(t, "no numbers &lt;here&gt;", Linkify("no numbers <here>", regionCode.US, LinkifyOptions{}))
+
+	// attributes are added in order of name and escaped
+	assert.Equal(t,
+		`<a href="tel:+44-20-7031-3000" class="tel" title="&#34;Office&#34;">+44 20 7031 3000</a>`,
+		Linkify("+44 20 7031 3000", regionCode.US, LinkifyOptions{Attributes: map[string]string{"title": `"Office"`, "class": "tel"}}),
+	)
+
+	// unless their names aren't valid
+	assert.Equal(t,
+		`<a href="tel:+44-20-7031-3000" data-tel="1">+44 20 7031 3000</a>`,
+		Linkify("+44 20 7031 3000", regionCode.US, LinkifyOptions{Attributes: map[string]string{
+			"data-tel": "1", `onclick="alert(1)" x`: "", "a/b": "", "": "", "\x00": "", "\xff": "",
+		}}),
+	)
+
+	// numbers are found with the leniency given, or VALID by default
+	possible := POSSIBLE
+	assert.Equal(t, `Call <a href="tel:+44-12345678">+44 1234 5678</a>`, Linkify("Call +44 1234 5678", regionCode.US, LinkifyOptions{Leniency: &possible}))
+	assert.Equal(t, `Call +44 1234 5678`, Linkify("Call +44 1234 5678", regionCode.US, LinkifyOptions{}))
+	assert.Equal(t, `total <a href="tel:+1-1234567">1234567</a>`, Linkify("total 1234567", regionCode.US, LinkifyOptions{Leniency: &possible}))
+	assert.Equal(t, `total 1234567`, Linkify("total 1234567", regionCode.US, LinkifyOptions{}))
+
+	// markup is left alone, anchors include inline elements a number is split
+	// across, unless they contain other text, and numbers in or around anchors
+	// aren't linked again
+	doc := `<p title="650 253 0001">Call <b>650</b> 253 0000 or <i><b>650</b></i> 253 0002</p>` +
+		`<p><a href="/contact">650 253 0003</a></p><p><b>Call 650</b> 253 0004</p>` +
+		`<p><a href="/contact">650</a> 253 0005</p>`
+	assert.Equal(t,
+		`<p title="650 253 0001">Call <a href="tel:+1-650-253-0000"><b>650</b> 253 0000</a> or `+
+			`<a href="tel:+1-650-253-0002"><i><b>650</b></i> 253 0002</a></p>`+
+			`<p><a href="/contact">650 253 0003</a></p><p><b>Call 650</b> 253 0004</p>`+
+			`<p><a href="/contact">650</a> 253 0005</p>`,
+		Linkify(doc, regionCode.US, LinkifyOptions{Format: LINKIFY_HTML}),
+	)
+	assert.Equal(t,
+		`Call <a href="tel:+1-650-253-0000">650 <b>253</b> 0000</a>`,
+		Linkify(`Call 650 <b>253</b> 0000`, regionCode.US, LinkifyOptions{Format: LINKIFY_HTML}),
+	)
+
+	// markdown, where numbers in the text or URL of links aren't linked again
+	md := "Call 650 253 0000, [650 253 0001](https://example.com), [site](https://example.com/?q=6502530003) or <tel:+16502530002>"
+	assert.Equal(t,
+		"Call [650 253 0000](tel:+1-650-253-0000), [650 253 0001](https://example.com), [site](https://example.com/?q=6502530003) or <tel:+16502530002>",
+		Linkify(md, regionCode.US, LinkifyOptions{Format: LINKIFY_MARKDOWN}),
+	)
+}
@@ -0,0 +1,61 @@
+// Package metadataxml loads territory metadata from upstream's
+// PhoneNumberMetadata.xml format at runtime, by running the same builder
+// cmd/buildmetadata uses. It is kept apart from the metadata package so that
+// only programs which compile metadata XML carry the builder.
+package metadataxml
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/nyaruka/phonenumbers/v2/internal/metadatabuilder"
+	"github.com/nyaruka/phonenumbers/v2/metadata"
+)
+
+// Load compiles territory metadata in upstream's PhoneNumberMetadata.xml format
+// into a container, without changing the active container.
+func Load(r io.Reader) (*metadata.Container, error) {
+	xml, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	coll, err := metadatabuilder.BuildPhoneMetadataCollection(xml, false, false, false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata XML: %w", err)
+	}
+	return metadata.NewContainer(coll, metadata.BuildCountryCodeToRegionMap(coll))
+}
+
+// ApplyOverlay patches the metadata in base with the territories in an overlay
+// document, returning a new container and leaving base untouched. The overlay
+// is written in upstream's PhoneNumberMetadata.xml format so that a local
+// correction can later be proposed upstream verbatim, but a territory already
+// in base need only contain what is being added to it, e.g.
+//
+//	<phoneNumberMetadata>
+//	  <territories>
+//	    <territory id="GB">
+//	      <mobile>
+//	        <possibleLengths national="10"/>
+//	        <nationalNumberPattern>7699\d{6}</nationalNumberPattern>
+//	      </mobile>
+//	    </territory>
+//	  </territories>
+//	</phoneNumberMetadata>
+//
+// Patterns are added as alternatives to the existing ones, possible lengths are
+// added to the existing ones, and number formats take precedence over the
+// existing ones. Territories not in base are added in full.
+func ApplyOverlay(base *metadata.Container, r io.Reader) (*metadata.Container, error) {
+	xml, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	coll, err := metadatabuilder.ApplyOverlay(base.Collection(), xml)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata overlay: %w", err)
+	}
+	return metadata.NewContainer(coll, metadata.BuildCountryCodeToRegionMap(coll))
+}
@@ -0,0 +1,175 @@
+package metadataxml_test
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nyaruka/phonenumbers/v2"
+	"github.com/nyaruka/phonenumbers/v2/metadata"
+	"github.com/nyaruka/phonenumbers/v2/metadata/metadataxml"
+)
+
+func TestLoad(t *testing.T) {
+	f, err := os.Open("../../testdata/PhoneNumberMetadataForTesting.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	mc, err := metadataxml.Load(f)
+	if err != nil {
+		t.Fatalf("error loading test metadata: %s", err)
+	}
+	if !mc.SupportedRegions()["AD"] {
+		t.Errorf("expected AD to be supported")
+	}
+	if regions := mc.CountryCodeToRegion()[1]; !slices.Equal(regions, []string{"US", "BB", "BS", "CA"}) {
+		t.Errorf("unexpected regions for 1: %v", regions)
+	}
+
+	tests := []struct {
+		xml string
+		err string
+	}{
+		{
+			xml: `<phoneNumberMetadata>`,
+			err: "invalid metadata XML: error unmarshalling XML: XML syntax error on line 1: unexpected EOF",
+		},
+		{
+			xml: `<phoneNumberMetadata><territories><territory id="XX" countryCode="999"><generalDesc><nationalNumberPattern>\d{7}</nationalNumberPattern></generalDesc><fixedLine><possibleLengths national="7,"/></fixedLine></territory></territories></phoneNumberMetadata>`,
+			err: "invalid metadata XML: leading, trailing or adjacent commas in possible length string 7,, these should only separate numbers or ranges",
+		},
+		{
+			xml: `<phoneNumberMetadata><territories><territory id="XX" countryCode="999" internationalPrefix="0(0"><generalDesc/></territory></territories></phoneNumberMetadata>`,
+			err: "invalid metadata XML: error parsing regexp: missing closing ): `0(0`",
+		},
+	}
+	for _, tc := range tests {
+		_, err := metadataxml.Load(strings.NewReader(tc.xml))
+		if err == nil || err.Error() != tc.err {
+			t.Errorf("expected error %q for %s, got %v", tc.err, tc.xml, err)
+		}
+	}
+}
+
+func TestApplyOverlay(t *testing.T) {
+	base, err := metadata.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	standard := phonenumbers.NewUtil(base)
+
+	num, err := standard.Parse("07650 123456", "GB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if standard.IsValidNumber(num) {
+		t.Fatalf("expected %s to be invalid without the overlay", num)
+	}
+
+	patched, err := metadataxml.ApplyOverlay(base, strings.NewReader(`
+<phoneNumberMetadata>
+  <territories>
+    <territory id="GB" nationalPrefixFormattingRule="$NP$FG">
+      <availableFormats>
+        <numberFormat pattern="(\d{4})(\d{3})(\d{3})">
+          <leadingDigits>7650</leadingDigits>
+          <format>$1 $2 $3</format>
+        </numberFormat>
+      </availableFormats>
+      <mobile>
+        <possibleLengths national="10"/>
+        <exampleNumber>7650123456</exampleNumber>
+        <nationalNumberPattern>7650\d{6}</nationalNumberPattern>
+      </mobile>
+    </territory>
+    <territory id="XX" countryCode="999" internationalPrefix="00">
+      <generalDesc>
+        <nationalNumberPattern>\d{7}</nationalNumberPattern>
+      </generalDesc>
+      <fixedLine>
+        <possibleLengths national="7"/>
+        <nationalNumberPattern>\d{7}</nationalNumberPattern>
+      </fixedLine>
+    </territory>
+  </territories>
+</phoneNumberMetadata>`))
+	if err != nil {
+		t.Fatalf("error applying overlay: %s", err)
+	}
+	util := phonenumbers.NewUtil(patched)
+
+	if !util.IsValidNumber(num) || util.GetNumberType(num) != phonenumbers.MOBILE {
+		t.Errorf("expected %s to be a valid mobile number with the overlay", num)
+	}
+	if formatted := util.Format(num, phonenumbers.NATIONAL); formatted != "07650 123 456" {
+		t.Errorf("unexpected national format: %s", formatted)
+	}
+	if formatted := util.Format(num, phonenumbers.INTERNATIONAL); formatted != "+44 7650 123 456" {
+		t.Errorf("unexpected international format: %s", formatted)
+	}
+	if gb, _ := patched.RegionMetadata("GB"); gb.GetMobile().GetExampleNumber() != "7650123456" {
+		t.Errorf("unexpected example number: %s", gb.GetMobile().GetExampleNumber())
+	}
+
+	// existing numbers are unaffected
+	for _, tc := range []struct {
+		number    string
+		typ       phonenumbers.PhoneNumberType
+		formatted string
+	}{
+		{"07912 345678", phonenumbers.MOBILE, "07912 345678"},
+		{"020 7031 3000", phonenumbers.FIXED_LINE, "020 7031 3000"},
+	} {
+		existing, err := util.Parse(tc.number, "GB")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if typ := util.GetNumberType(existing); typ != tc.typ {
+			t.Errorf("unexpected type %d for %s", typ, tc.number)
+		}
+		if formatted := util.Format(existing, phonenumbers.NATIONAL); formatted != tc.formatted {
+			t.Errorf("unexpected national format for %s: %s", tc.number, formatted)
+		}
+	}
+
+	// new territories are added in full
+	xx, err := util.Parse("1234567", "XX")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !util.IsValidNumber(xx) || util.GetRegionCodeForNumber(xx) != "XX" {
+		t.Errorf("expected %s to be valid for XX", xx)
+	}
+
+	// and base is untouched
+	if standard.IsValidNumber(num) || standard.GetSupportedRegions()["XX"] {
+		t.Errorf("expected base metadata to be unchanged")
+	}
+
+	tests := []struct {
+		xml string
+		err string
+	}{
+		{
+			xml: `<phoneNumberMetadata><territories><territory id="GB" countryCode="33"/></territories></phoneNumberMetadata>`,
+			err: "invalid metadata overlay: overlay territory GB has country code 33, base metadata has 44",
+		},
+		{
+			xml: `<phoneNumberMetadata><territories><territory id="GB"><mobile><possibleLengths national=""/></mobile></territory></territories></phoneNumberMetadata>`,
+			err: "invalid metadata overlay: empty possibleLength string found",
+		},
+		{
+			xml: `<phoneNumberMetadata><territories><territory id="GB"><mobile><possibleLengths national="10"/><nationalNumberPattern>7650(</nationalNumberPattern></mobile></territory></territories></phoneNumberMetadata>`,
+			err: "invalid metadata overlay: error parsing regexp: missing closing ): `7650(`",
+		},
+	}
+	for _, tc := range tests {
+		_, err := metadataxml.ApplyOverlay(base, strings.NewReader(tc.xml))
+		if err == nil || err.Error() != tc.err {
+			t.Errorf("expected error %q for %s, got %v", tc.err, tc.xml, err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package metadata
+
+import "regexp/syntax"
+
+// NationalPrefixDigits is the set of digits that a match of a territory's
+// NationalPrefixForParsing pattern can start with. It lets parsing skip
+// stripping a national prefix from numbers that can't start with one.
+type NationalPrefixDigits struct {
+	digits [10]bool
+
+	// nullable is set when the pattern can match the empty string, and so can
+	// match at the start of any number.
+	nullable bool
+}
+
+// MayStart reports whether the national prefix pattern might match at the
+// start of nsn, a non-empty string of ASCII digits.
+func (d *NationalPrefixDigits) MayStart(nsn string) bool {
+	return d.nullable || d.digits[nsn[0]-'0']
+}
+
+// anyNationalPrefixDigits is returned for patterns that can start anywhere.
+var anyNationalPrefixDigits = &NationalPrefixDigits{nullable: true}
+
+func newNationalPrefixDigits(md *PhoneMetadata) *NationalPrefixDigits {
+	d := &NationalPrefixDigits{}
+	if pattern := md.GetNationalPrefixForParsing(); pattern != "" {
+		re, err := syntax.Parse(pattern, syntax.Perl)
+		if err != nil {
+			// leave invalid patterns to fail wherever they're compiled
+			return anyNationalPrefixDigits
+		}
+		d.digits, d.nullable = firstDigits(re.Simplify())
+	}
+	return d
+}
+
+// firstDigits returns the digits a non-empty match of re can start with, and
+// whether re can match the empty string. Constructs it doesn't understand are
+// treated as matching anything.
+func firstDigits(re *syntax.Regexp) (digits [10]bool, nullable bool) {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return digits, false
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return digits, true
+	case syntax.OpLiteral:
+		if len(re.Rune) == 0 {
+			return digits, true
+		}
+		if r := re.Rune[0]; r >= '0' && r <= '9' {
+			digits[r-'0'] = true
+		}
+		return digits, false
+	case syntax.OpCharClass:
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			for r := max(re.Rune[i], '0'); r <= min(re.Rune[i+1], '9'); r++ {
+				digits[r-'0'] = true
+			}
+		}
+		return digits, false
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return [10]bool{true, true, true, true, true, true, true, true, true, true}, false
+	case syntax.OpCapture, syntax.OpPlus:
+		return firstDigits(re.Sub[0])
+	case syntax.OpStar, syntax.OpQuest:
+		digits, _ = firstDigits(re.Sub[0])
+		return digits, true
+	case syntax.OpRepeat:
+		digits, nullable = firstDigits(re.Sub[0])
+		return digits, nullable || re.Min == 0
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			subDigits, subNullable := firstDigits(sub)
+			for i := range digits {
+				digits[i] = digits[i] || subDigits[i]
+			}
+			if !subNullable {
+				return digits, false
+			}
+		}
+		return digits, true
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			subDigits, subNullable := firstDigits(sub)
+			for i := range digits {
+				digits[i] = digits[i] || subDigits[i]
+			}
+			nullable = nullable || subNullable
+		}
+		return digits, nullable
+	}
+	return digits, true
+}
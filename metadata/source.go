@@ -4,6 +4,9 @@ package metadata
 import (
 	_ "embed"
 	"errors"
+	"io"
+	"os"
+	"sync/atomic"
 
 	"github.com/nyaruka/phonenumbers/v2/internal/serialize"
 	"google.golang.org/protobuf/proto"
@@ -25,11 +28,15 @@ const (
 
 // Container bundles all of the metadata-derived lookup state used by the
 // library. The package keeps a single active container (current), built from
-// the embedded metadata during init. Bundling the state behind one swappable
-// value is the seam that lets tests run against alternate metadata (e.g.
-// upstream's synthetic PhoneNumberMetadataForTesting data) without changing the
-// public, package-level API.
+// the embedded metadata during init, which the package-level API reads. Any
+// other container can be bound to a phonenumbers.Util (via NewUtil) to run
+// against alternate metadata (e.g. upstream's synthetic
+// PhoneNumberMetadataForTesting data) alongside the active one. A Container is
+// immutable once built and safe for concurrent use.
 type Container struct {
+	// The metadata release this container was built from, if known.
+	version string
+
 	// The parsed metadata collection this container was built from.
 	metadataCollection *PhoneMetadataCollection
 
@@ -56,19 +63,24 @@ type Container struct {
 
 	// The set of calling codes that map to the non-geo entity region ("001").
 	countryCodesForNonGeographicalRegion map[int]bool
+
+	// The digits each territory's national prefix can start with.
+	nationalPrefixDigits map[*PhoneMetadata]*NationalPrefixDigits
 }
 
 // current is the active metadata container. It is populated from the embedded
 // metadata by init during package initialization. Tests may swap it via Use to
-// exercise the library against alternate metadata.
-var current *Container
+// exercise the library against alternate metadata, and a Watcher swaps it when
+// the metadata it watches changes. It is only ever replaced wholesale, so
+// readers see either the old or the new container, never a mix.
+var current atomic.Pointer[Container]
 
 func init() {
 	c, err := Load()
 	if err != nil {
 		panic(err)
 	}
-	current = c
+	current.Store(c)
 }
 
 // Load reads the embedded metadata and builds a fresh container from it without
@@ -86,7 +98,43 @@ func Load() (*Container, error) {
 		return nil, err
 	}
 
-	return NewContainer(coll, regionMap.Map)
+	mc, err := NewContainer(coll, regionMap.Map)
+	if err != nil {
+		return nil, err
+	}
+	mc.version = Version
+	return mc, nil
+}
+
+// LoadFrom reads territory metadata in the format cmd/buildmetadata writes to
+// metadata.xml.gz (a gzipped PhoneMetadataCollection protobuf) and builds a
+// fresh container from it without changing the active container. The
+// country-code-to-region map is derived from the collection itself, the same
+// way cmd/buildmetadata derives countrycode_to_region.xml.gz, so that blob is
+// not needed.
+func LoadFrom(r io.Reader) (*Container, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	coll, err := decodeCollection(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewContainer(coll, BuildCountryCodeToRegionMap(coll))
+}
+
+// LoadFile is LoadFrom reading from the file at path.
+func LoadFile(path string) (*Container, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadFrom(f)
 }
 
 // NewContainer builds a fully populated Container from a parsed metadata
@@ -108,9 +156,11 @@ func NewContainer(coll *PhoneMetadataCollection, countryCodeToRegion map[int][]s
 		supportedRegions:                        make(map[string]bool, 320),
 		supportedCallingCodes:                   make(map[int]bool, 320),
 		countryCodesForNonGeographicalRegion:    make(map[int]bool, 16),
+		nationalPrefixDigits:                    make(map[*PhoneMetadata]*NationalPrefixDigits, len(metadataList)),
 	}
 
 	for _, meta := range metadataList {
+		mc.nationalPrefixDigits[meta] = newNationalPrefixDigits(meta)
 		region := meta.GetId()
 		if region == regionCodeForNonGeoEntity {
 			// it's a non geographical entity
@@ -151,51 +201,134 @@ func NewContainer(coll *PhoneMetadataCollection, countryCodeToRegion map[int][]s
 	return mc, nil
 }
 
+// BuildCountryCodeToRegionMap builds a mapping from a country calling code to
+// the region codes which denote the country/region represented by that country
+// code. In the case of multiple countries sharing a calling code, such as the
+// NANPA countries, the one indicated with "isMainCountryForCode" in the
+// metadata should be first.
+func BuildCountryCodeToRegionMap(metadataCollection *PhoneMetadataCollection) map[int][]string {
+	countryCodeToRegionCodeMap := make(map[int][]string)
+	for _, md := range metadataCollection.Metadata {
+		regionCode := md.GetId()
+		countryCode := int(md.GetCountryCode())
+		_, present := countryCodeToRegionCodeMap[countryCode]
+		if present {
+			phoneList := countryCodeToRegionCodeMap[countryCode]
+			if md.GetMainCountryForCode() {
+				phoneList = append([]string{regionCode}, phoneList...)
+			} else {
+				phoneList = append(phoneList, regionCode)
+			}
+			countryCodeToRegionCodeMap[countryCode] = phoneList
+		} else {
+			// For most countries, there will be only one region code for the country calling code.
+			phoneList := []string{}
+			if regionCode != "" { // For alternate formats, there are no region codes at all.
+				phoneList = append(phoneList, regionCode)
+			}
+			countryCodeToRegionCodeMap[countryCode] = phoneList
+		}
+	}
+	return countryCodeToRegionCodeMap
+}
+
 // Use swaps the active metadata container, returning a function that restores
 // the previously active container. It is intended for tests that need to run
 // against alternate (e.g. synthetic) metadata; callers must invoke the returned
 // restore function (typically via t.Cleanup) and must not run such tests in
-// parallel, since the active container is process-global.
+// parallel, since the active container is process-global. Tests that need to
+// run in parallel should bind a phonenumbers.Util to the container instead.
 func Use(c *Container) (restore func()) {
-	prev := current
-	current = c
-	return func() { current = prev }
+	prev := current.Swap(c)
+	return func() { current.Store(prev) }
 }
 
+// Current returns the active metadata container: the one built from the
+// embedded metadata at init, or whichever container was last swapped in via Use.
+func Current() *Container { return current.Load() }
+
+// Version returns the metadata release the container was built from: Version
+// for the embedded metadata, whatever a Watcher found for watched metadata, or
+// "" if unknown.
+func (c *Container) Version() string { return c.version }
+
 // RegionMetadata returns the metadata for the given region code, if supported.
-func RegionMetadata(region string) (*PhoneMetadata, bool) {
-	v, ok := current.regionToMetadataMap[region]
+func (c *Container) RegionMetadata(region string) (*PhoneMetadata, bool) {
+	v, ok := c.regionToMetadataMap[region]
 	return v, ok
 }
 
 // NonGeoMetadata returns the metadata for the given non-geographical country
 // calling code, if any.
-func NonGeoMetadata(countryCode int) (*PhoneMetadata, bool) {
-	v, ok := current.countryCodeToNonGeographicalMetadataMap[countryCode]
+func (c *Container) NonGeoMetadata(countryCode int) (*PhoneMetadata, bool) {
+	v, ok := c.countryCodeToNonGeographicalMetadataMap[countryCode]
 	return v, ok
 }
 
 // IsNANPARegion reports whether region shares country calling code 1.
-func IsNANPARegion(region string) bool {
-	_, ok := current.nanpaRegions[region]
+func (c *Container) IsNANPARegion(region string) bool {
+	_, ok := c.nanpaRegions[region]
 	return ok
 }
 
+// SupportedRegions returns the set of regions the container supports.
+func (c *Container) SupportedRegions() map[string]bool { return c.supportedRegions }
+
+// SupportedCallingCodes returns the set of calling codes the container supports.
+func (c *Container) SupportedCallingCodes() map[int]bool { return c.supportedCallingCodes }
+
+// CountryCodesForNonGeographicalRegion returns the set of calling codes that map
+// to the non-geo entity region ("001").
+func (c *Container) CountryCodesForNonGeographicalRegion() map[int]bool {
+	return c.countryCodesForNonGeographicalRegion
+}
+
+// CountryCodeToRegion returns the map from country calling code to its region
+// codes.
+func (c *Container) CountryCodeToRegion() map[int][]string { return c.countryCodeToRegion }
+
+// NationalPrefixDigits returns the digits the national prefix of md, one of
+// the container's territories, can start with.
+func (c *Container) NationalPrefixDigits(md *PhoneMetadata) *NationalPrefixDigits {
+	if d, ok := c.nationalPrefixDigits[md]; ok {
+		return d
+	}
+	return anyNationalPrefixDigits
+}
+
+// Collection returns the metadata collection the container was built from.
+func (c *Container) Collection() *PhoneMetadataCollection { return c.metadataCollection }
+
+// RegionMetadata returns the metadata for the given region code in the active
+// container, if supported.
+func RegionMetadata(region string) (*PhoneMetadata, bool) {
+	return current.Load().RegionMetadata(region)
+}
+
+// NonGeoMetadata returns the metadata for the given non-geographical country
+// calling code in the active container, if any.
+func NonGeoMetadata(countryCode int) (*PhoneMetadata, bool) {
+	return current.Load().NonGeoMetadata(countryCode)
+}
+
+// IsNANPARegion reports whether region shares country calling code 1.
+func IsNANPARegion(region string) bool { return current.Load().IsNANPARegion(region) }
+
 // SupportedRegions returns the set of regions the library supports.
-func SupportedRegions() map[string]bool { return current.supportedRegions }
+func SupportedRegions() map[string]bool { return current.Load().SupportedRegions() }
 
 // SupportedCallingCodes returns the set of calling codes the library supports.
-func SupportedCallingCodes() map[int]bool { return current.supportedCallingCodes }
+func SupportedCallingCodes() map[int]bool { return current.Load().SupportedCallingCodes() }
 
 // CountryCodesForNonGeographicalRegion returns the set of calling codes that map
 // to the non-geo entity region ("001").
 func CountryCodesForNonGeographicalRegion() map[int]bool {
-	return current.countryCodesForNonGeographicalRegion
+	return current.Load().CountryCodesForNonGeographicalRegion()
 }
 
 // CountryCodeToRegion returns the map from country calling code to its region
 // codes.
-func CountryCodeToRegion() map[int][]string { return current.countryCodeToRegion }
+func CountryCodeToRegion() map[int][]string { return current.Load().CountryCodeToRegion() }
 
 var (
 	currCollection *PhoneMetadataCollection
@@ -210,7 +343,18 @@ func Collection() (*PhoneMetadataCollection, error) {
 		return currCollection, nil
 	}
 
-	rawBytes, err := serialize.DecodeUnzip(numberData)
+	c, err := decodeCollection(numberData)
+	if err != nil {
+		return nil, err
+	}
+	currCollection = c
+	reloadMetadata = false
+	return c, nil
+}
+
+// decodeCollection gunzips and unmarshals a metadata collection blob.
+func decodeCollection(data []byte) (*PhoneMetadataCollection, error) {
+	rawBytes, err := serialize.DecodeUnzip(data)
 	if err != nil {
 		return nil, err
 	}
@@ -219,7 +363,5 @@ func Collection() (*PhoneMetadataCollection, error) {
 	if err = proto.Unmarshal(rawBytes, c); err != nil {
 		return nil, err
 	}
-	currCollection = c
-	reloadMetadata = false
 	return c, nil
 }
@@ -0,0 +1,217 @@
+package metadata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// WatchedMetadataFile is the file a Watcher loads metadata from, in the
+	// format cmd/buildmetadata writes to metadata/data/metadata.xml.gz.
+	WatchedMetadataFile = "metadata.xml.gz"
+
+	// WatchedVersionFile is the optional file a Watcher reads the metadata
+	// release from (e.g. "v9.0.37"). Without it, the version reported for
+	// watched metadata is a digest of its contents.
+	WatchedVersionFile = "VERSION"
+)
+
+// Watcher keeps the active container in sync with metadata in a directory. It
+// polls the directory for changes and, when they load and validate, swaps the
+// active container atomically, so calls already in flight finish against the
+// container they started with. Metadata that fails to load is reported via Err
+// and leaves the active container untouched. Write files into the directory
+// with an atomic rename to avoid loading them half-written.
+type Watcher struct {
+	dir      string
+	interval time.Duration
+
+	mu     sync.Mutex
+	subs   []func(old, new string)
+	stamp  string
+	digest string
+	err    error
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// Watch loads the metadata in dir, makes it the active container, and starts
+// polling dir for changes every interval. It returns an error, and leaves the
+// active container unchanged, if interval isn't positive or the initial load
+// fails. Callers must Close the returned watcher to stop polling.
+func Watch(dir string, interval time.Duration) (*Watcher, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid watch interval: %s", interval)
+	}
+
+	w := &Watcher{
+		dir:      dir,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if err := w.Reload(); err != nil {
+		return nil, err
+	}
+
+	go w.run()
+	return w, nil
+}
+
+// Subscribe registers fn to be called with the old and new container versions
+// each time the watcher swaps the active container. Callbacks run on the
+// goroutine that performed the reload, after the swap.
+func (w *Watcher) Subscribe(fn func(old, new string)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subs = append(w.subs, fn)
+}
+
+// Err returns the error from the most recent reload, or nil if it succeeded.
+func (w *Watcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.err
+}
+
+// Reload loads the metadata in the watched directory now rather than waiting
+// for the next poll. It is a no-op if the contents haven't changed since the
+// last successful load.
+func (w *Watcher) Reload() error {
+	w.mu.Lock()
+
+	mc, err := w.load()
+	w.err = err
+	if err != nil || mc == nil {
+		w.mu.Unlock()
+		return err
+	}
+
+	prev := current.Swap(mc)
+	subs := slices.Clone(w.subs)
+	w.mu.Unlock()
+
+	for _, fn := range subs {
+		fn(prev.Version(), mc.Version())
+	}
+	return nil
+}
+
+// Close stops polling. It does not change the active container.
+func (w *Watcher) Close() {
+	w.stopOnce.Do(func() { close(w.stop) })
+	<-w.done
+}
+
+func (w *Watcher) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if w.changed() {
+				w.Reload()
+			}
+		}
+	}
+}
+
+// changed reports whether the watched files have been modified since the last
+// reload attempt.
+func (w *Watcher) changed() bool {
+	stamp := w.statFiles()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return stamp != w.stamp
+}
+
+// load reads and validates the watched metadata, returning nil if it is
+// unchanged since the last successful load. Must be called with w.mu held.
+func (w *Watcher) load() (*Container, error) {
+	// stat before reading so that a write racing with the read is seen as a
+	// change on the next poll
+	w.stamp = w.statFiles()
+
+	data, err := os.ReadFile(filepath.Join(w.dir, WatchedMetadataFile))
+	if err != nil {
+		return nil, err
+	}
+
+	version := ""
+	raw, err := os.ReadFile(filepath.Join(w.dir, WatchedVersionFile))
+	if err == nil {
+		version = strings.TrimSpace(string(raw))
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	h := sha256.New()
+	h.Write(data)
+	h.Write([]byte(version))
+	digest := hex.EncodeToString(h.Sum(nil))
+	if digest == w.digest {
+		return nil, nil
+	}
+
+	mc, err := LoadFrom(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error loading %s: %w", WatchedMetadataFile, err)
+	}
+	if err := validate(mc); err != nil {
+		return nil, fmt.Errorf("error loading %s: %w", WatchedMetadataFile, err)
+	}
+
+	if version == "" {
+		version = "sha256:" + digest[:12]
+	}
+	mc.version = version
+	w.digest = digest
+	return mc, nil
+}
+
+// statFiles summarizes the size and modification time of the watched files.
+func (w *Watcher) statFiles() string {
+	var sb strings.Builder
+	for _, name := range []string{WatchedMetadataFile, WatchedVersionFile} {
+		if fi, err := os.Stat(filepath.Join(w.dir, name)); err == nil {
+			fmt.Fprintf(&sb, "%s:%d:%d;", name, fi.Size(), fi.ModTime().UnixNano())
+		}
+	}
+	return sb.String()
+}
+
+// validate checks that every territory in a container carries the fields the
+// library relies on, so that a bad build is rejected before it goes live.
+func validate(c *Container) error {
+	for _, md := range c.metadataCollection.GetMetadata() {
+		if md.GetId() == "" {
+			return fmt.Errorf("territory with country code %d has no id", md.GetCountryCode())
+		}
+		if md.GetCountryCode() <= 0 {
+			return fmt.Errorf("territory %s has no country code", md.GetId())
+		}
+		if md.GetGeneralDesc() == nil {
+			return fmt.Errorf("territory %s has no general description", md.GetId())
+		}
+	}
+	return nil
+}
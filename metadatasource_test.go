@@ -1,7 +1,13 @@
 package phonenumbers
 
 import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/nyaruka/phonenumbers/v2/metadata"
 	"github.com/stretchr/testify/assert"
@@ -87,3 +93,145 @@ func TestMetadataInjectionSeam(t *testing.T) {
 	_, err = metadata.NewContainer(&PhoneMetadataCollection{}, nil)
 	assert.ErrorIs(t, err, ErrEmptyMetadata)
 }
+
+// TestUtilBoundToContainer checks that a Util reads only the container it was
+// built from, so it can run side by side with the package-level API (and in
+// parallel with other such tests) without touching the active metadata.
+func TestUtilBoundToContainer(t *testing.T) {
+	t.Parallel()
+
+	coll, ccToRegion := syntheticCollection()
+	mc, err := metadata.NewContainer(coll, ccToRegion)
+	require.NoError(t, err)
+
+	synthetic := NewUtil(mc)
+	assert.Same(t, mc, synthetic.Metadata())
+	assert.Equal(t, map[string]bool{"XX": true}, synthetic.GetSupportedRegions())
+	assert.Equal(t, 999, synthetic.GetCountryCodeForRegion("XX"))
+	assert.Equal(t, 0, synthetic.GetCountryCodeForRegion("US"))
+
+	num, err := synthetic.Parse("1234567", "XX")
+	require.NoError(t, err)
+	assert.Equal(t, int32(999), num.GetCountryCode())
+	assert.Equal(t, FIXED_LINE, synthetic.GetNumberType(num))
+	assert.True(t, synthetic.IsValidNumber(num))
+	assert.Equal(t, "+9991234567", synthetic.Format(num, E164))
+
+	_, err = synthetic.Parse("+1 650 253 0000", "XX")
+	assert.ErrorIs(t, err, ErrInvalidCountryCode)
+
+	// A Util over the embedded metadata is unaffected by the synthetic one.
+	embedded, err := metadata.Load()
+	require.NoError(t, err)
+	standard := NewUtil(embedded)
+	assert.NotContains(t, standard.GetSupportedRegions(), "XX")
+	us, err := standard.Parse("+1 650 253 0000", "XX")
+	require.NoError(t, err)
+	assert.Equal(t, "US", standard.GetRegionCodeForNumber(us))
+	assert.Equal(t, "(650) 253-0000", standard.Format(us, NATIONAL))
+
+	// Short numbers are looked up in the regions of the Util's container, so
+	// 911 is only valid for the US where the US is a region.
+	emergency := &PhoneNumber{CountryCode: proto.Int32(1), NationalNumber: proto.Uint64(911)}
+	assert.True(t, standard.IsValidShortNumber(emergency))
+	assert.True(t, standard.IsValidShortNumberForRegion(emergency, "US"))
+	assert.False(t, synthetic.IsValidShortNumber(emergency))
+	assert.False(t, synthetic.IsValidShortNumberForRegion(emergency, "US"))
+	assert.Equal(t, UNKNOWN_COST, synthetic.GetExpectedCost(emergency))
+
+	var found []string
+	for m := range synthetic.FindNumbers("call 1234567 now", "XX") {
+		found = append(found, m.RawString())
+	}
+	assert.Equal(t, []string{"1234567"}, found)
+}
+
+func TestLoadMetadataFromFile(t *testing.T) {
+	embedded, err := metadata.Load()
+	require.NoError(t, err)
+
+	loaded, err := metadata.LoadFile("metadata/data/metadata.xml.gz")
+	require.NoError(t, err)
+	assert.Equal(t, embedded.SupportedRegions(), loaded.SupportedRegions())
+	assert.Equal(t, embedded.CountryCodeToRegion(), loaded.CountryCodeToRegion())
+
+	num, err := NewUtil(loaded).Parse("+44 20 7031 3000", "")
+	require.NoError(t, err)
+	assert.Equal(t, "GB", NewUtil(loaded).GetRegionCodeForNumber(num))
+
+	_, err = metadata.LoadFile("metadata/data/missing.xml.gz")
+	assert.Error(t, err)
+
+	_, err = metadata.LoadFrom(strings.NewReader("not metadata"))
+	assert.Error(t, err)
+}
+
+func TestMetadataWatcher(t *testing.T) {
+	t.Cleanup(metadata.Use(metadata.Current()))
+
+	embedded, err := os.ReadFile("metadata/data/metadata.xml.gz")
+	require.NoError(t, err)
+	testMC, err := loadTestMetadataContainer()
+	require.NoError(t, err)
+	raw, err := proto.Marshal(testMC.Collection())
+	require.NoError(t, err)
+	var synthetic bytes.Buffer
+	zw := gzip.NewWriter(&synthetic)
+	zw.Write(raw)
+	require.NoError(t, zw.Close())
+
+	dir := t.TempDir()
+	write := func(name string, data []byte) {
+		tmp := filepath.Join(dir, name+".tmp")
+		require.NoError(t, os.WriteFile(tmp, data, 0644))
+		require.NoError(t, os.Rename(tmp, filepath.Join(dir, name)))
+	}
+
+	// an empty directory can't be watched
+	_, err = metadata.Watch(dir, time.Millisecond)
+	assert.Error(t, err)
+
+	// nor can anything be watched without a positive interval
+	write(metadata.WatchedMetadataFile, embedded)
+	_, err = metadata.Watch(dir, 0)
+	assert.EqualError(t, err, "invalid watch interval: 0s")
+	_, err = metadata.Watch(dir, -time.Second)
+	assert.EqualError(t, err, "invalid watch interval: -1s")
+
+	write(metadata.WatchedMetadataFile, embedded)
+	write(metadata.WatchedVersionFile, []byte("v9.0.37\n"))
+	w, err := metadata.Watch(dir, time.Millisecond)
+	require.NoError(t, err)
+	defer w.Close()
+
+	assert.Equal(t, "v9.0.37", metadata.Current().Version())
+	assert.Len(t, GetRegionCodesForCountryCode(1), 25)
+
+	changes := make(chan [2]string, 10)
+	w.Subscribe(func(old, new string) { changes <- [2]string{old, new} })
+
+	write(metadata.WatchedVersionFile, []byte("test"))
+	write(metadata.WatchedMetadataFile, synthetic.Bytes())
+	require.Eventually(t, func() bool {
+		return metadata.Current().Version() == "test" && len(GetRegionCodesForCountryCode(1)) == 4
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, [2]string{"v9.0.37", "test"}, <-changes)
+	assert.NoError(t, w.Err())
+
+	// broken metadata is reported and the active container is kept
+	active := metadata.Current()
+	write(metadata.WatchedMetadataFile, []byte("not metadata"))
+	require.Eventually(t, func() bool { return w.Err() != nil }, time.Second, time.Millisecond)
+	assert.Same(t, active, metadata.Current())
+
+	// without a version file the version is derived from the contents
+	require.NoError(t, os.Remove(filepath.Join(dir, metadata.WatchedVersionFile)))
+	write(metadata.WatchedMetadataFile, embedded)
+	require.Eventually(t, func() bool { return w.Err() == nil && len(GetRegionCodesForCountryCode(1)) == 25 }, time.Second, time.Millisecond)
+	assert.Regexp(t, `^sha256:[0-9a-f]{12}$`, metadata.Current().Version())
+
+	w.Close()
+	write(metadata.WatchedMetadataFile, synthetic.Bytes())
+	time.Sleep(10 * time.Millisecond)
+	assert.Len(t, GetRegionCodesForCountryCode(1), 25)
+}
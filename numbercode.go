@@ -0,0 +1,82 @@
+package phonenumbers
+
+import (
+	"errors"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrNumberNotEncodable = errors.New("the phone number can't be encoded in 64 bits")
+	ErrInvalidNumberCode  = errors.New("the code is not a valid encoded phone number")
+)
+
+// The layout of an encoded number, from the most significant bit.
+const (
+	codeCountryCodeBits    = 10
+	codeNationalNumberBits = 49
+	codeLeadingZerosBits   = 5
+
+	codeNationalNumberShift = codeLeadingZerosBits
+	codeCountryCodeShift    = codeNationalNumberShift + codeNationalNumberBits
+
+	maxCodeCountryCode    = 999
+	maxCodeNationalNumber = 1<<codeNationalNumberBits - 1
+	maxCodeLeadingZeros   = maxLengthForNSN
+)
+
+// EncodeNumber packs the country code, national number and leading zeros of
+// number into a uint64, e.g. for use as a key in an index of numbers. Two
+// numbers have the same code exactly when they are the same number, and codes
+// sort by country code, then national number, then number of leading zeros.
+// The raw input, extension, country code source and preferred domestic
+// carrier code aren't encoded.
+//
+// The country code takes the top 10 bits, the national number the next 49
+// and the leading zeros the bottom 5. That holds any number E.164 allows,
+// which is at most 15 digits long, but not the longest numbers Parse accepts,
+// for which ErrNumberNotEncodable is returned.
+func EncodeNumber(number *PhoneNumber) (uint64, error) {
+	countryCode := number.GetCountryCode()
+	nationalNumber := number.GetNationalNumber()
+	if countryCode < 0 || countryCode > maxCodeCountryCode || nationalNumber > maxCodeNationalNumber {
+		return 0, ErrNumberNotEncodable
+	}
+
+	leadingZeros := int32(0)
+	if number.GetItalianLeadingZero() {
+		leadingZeros = number.GetNumberOfLeadingZeros()
+		if leadingZeros < 1 || leadingZeros > maxCodeLeadingZeros {
+			return 0, ErrNumberNotEncodable
+		}
+	}
+
+	return uint64(countryCode)<<codeCountryCodeShift |
+		nationalNumber<<codeNationalNumberShift |
+		uint64(leadingZeros), nil
+}
+
+// DecodeNumber unpacks a number encoded by EncodeNumber. Fields are set as
+// Parse would set them, e.g. the number of leading zeros is only set if it
+// isn't the default of one, so decoding the code of a parsed number gives a
+// number equal to it.
+func DecodeNumber(code uint64) (*PhoneNumber, error) {
+	countryCode := int32(code >> codeCountryCodeShift)
+	nationalNumber := code >> codeNationalNumberShift & maxCodeNationalNumber
+	leadingZeros := int32(code & (1<<codeLeadingZerosBits - 1))
+	if countryCode > maxCodeCountryCode || leadingZeros > maxCodeLeadingZeros {
+		return nil, ErrInvalidNumberCode
+	}
+
+	number := &PhoneNumber{
+		CountryCode:    proto.Int32(countryCode),
+		NationalNumber: proto.Uint64(nationalNumber),
+	}
+	if leadingZeros > 0 {
+		number.ItalianLeadingZero = proto.Bool(true)
+		if leadingZeros != 1 {
+			number.NumberOfLeadingZeros = proto.Int32(leadingZeros)
+		}
+	}
+	return number, nil
+}
@@ -0,0 +1,86 @@
+package phonenumbers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestEncodeNumber(t *testing.T) {
+	var numbers []*PhoneNumber
+	for region := range GetSupportedRegions() {
+		for numberType := range GetSupportedTypesForRegion(region) {
+			if num := GetExampleNumberForTypeInRegion(region, numberType); num != nil {
+				numbers = append(numbers, num)
+			}
+		}
+	}
+	for callingCode := range GetSupportedGlobalNetworkCallingCodes() {
+		if num := GetExampleNumberForNonGeoEntity(callingCode); num != nil {
+			numbers = append(numbers, num)
+		}
+	}
+	require.NotEmpty(t, numbers)
+
+	codes := make(map[uint64]*PhoneNumber)
+	for _, num := range numbers {
+		code, err := EncodeNumber(num)
+		require.NoError(t, err, "error encoding %v", num)
+
+		decoded, err := DecodeNumber(code)
+		require.NoError(t, err, "error decoding %v", num)
+		assert.True(t, proto.Equal(num, decoded), "round trip mismatch for %v, got %v", num, decoded)
+
+		if other, ok := codes[code]; ok {
+			assert.True(t, proto.Equal(num, other), "code collision between %v and %v", num, other)
+		}
+		codes[code] = num
+	}
+
+	// codes sort by country code, then national number, then leading zeros
+	ordered := []*PhoneNumber{
+		{CountryCode: proto.Int32(1), NationalNumber: proto.Uint64(6502530000)},
+		{CountryCode: proto.Int32(1), NationalNumber: proto.Uint64(6502530001)},
+		{CountryCode: proto.Int32(39), NationalNumber: proto.Uint64(236618300)},
+		{CountryCode: proto.Int32(39), NationalNumber: proto.Uint64(236618300), ItalianLeadingZero: proto.Bool(true)},
+		{CountryCode: proto.Int32(39), NationalNumber: proto.Uint64(236618300), ItalianLeadingZero: proto.Bool(true), NumberOfLeadingZeros: proto.Int32(2)},
+		{CountryCode: proto.Int32(44), NationalNumber: proto.Uint64(1)},
+		{CountryCode: proto.Int32(999), NationalNumber: proto.Uint64(99999999999999)},
+	}
+	var prev uint64
+	for i, num := range ordered {
+		code, err := EncodeNumber(num)
+		require.NoError(t, err)
+		if i > 0 {
+			assert.Greater(t, code, prev, "code for %v should sort after previous", num)
+		}
+		prev = code
+	}
+
+	// other fields are dropped
+	code, err := EncodeNumber(&PhoneNumber{CountryCode: proto.Int32(1), NationalNumber: proto.Uint64(6502530000), Extension: proto.String("123"), RawInput: proto.String("650 253 0000")})
+	assert.NoError(t, err)
+	decoded, err := DecodeNumber(code)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(usNumber(), decoded))
+
+	// numbers too big to encode
+	for _, num := range []*PhoneNumber{
+		{CountryCode: proto.Int32(1000), NationalNumber: proto.Uint64(1)},
+		{CountryCode: proto.Int32(-1), NationalNumber: proto.Uint64(1)},
+		{CountryCode: proto.Int32(1), NationalNumber: proto.Uint64(12345678901234567)},
+		{CountryCode: proto.Int32(39), NationalNumber: proto.Uint64(1), ItalianLeadingZero: proto.Bool(true), NumberOfLeadingZeros: proto.Int32(18)},
+		{CountryCode: proto.Int32(39), NationalNumber: proto.Uint64(1), ItalianLeadingZero: proto.Bool(true), NumberOfLeadingZeros: proto.Int32(0)},
+	} {
+		_, err := EncodeNumber(num)
+		assert.Equal(t, ErrNumberNotEncodable, err, "expected error encoding %v", num)
+	}
+
+	// codes that no number encodes to
+	_, err = DecodeNumber(1000 << codeCountryCodeShift)
+	assert.Equal(t, ErrInvalidNumberCode, err)
+	_, err = DecodeNumber(1<<codeCountryCodeShift | 18)
+	assert.Equal(t, ErrInvalidNumberCode, err)
+}
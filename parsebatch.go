@@ -0,0 +1,185 @@
+package phonenumbers
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"runtime"
+	"sync"
+)
+
+// BatchOptions are the options for ParseBatch.
+type BatchOptions struct {
+	// Workers is the number of goroutines parsing in parallel, defaulting to
+	// GOMAXPROCS.
+	Workers int
+
+	// Stats, if not nil, is reset and then updated with each number yielded,
+	// so that it holds the totals for the batch once iteration finishes.
+	Stats *BatchStats
+}
+
+// BatchStats are the aggregate results of a ParseBatch.
+type BatchStats struct {
+	// Parsed is the number of inputs which parsed.
+	Parsed int
+
+	// Failed is the number of inputs which failed to parse.
+	Failed int
+
+	// ByValidationResult counts the parsed numbers by IsPossibleNumberWithReason.
+	ByValidationResult map[ValidationResult]int
+
+	// ByNumberType counts the parsed numbers by GetNumberType.
+	ByNumberType map[PhoneNumberType]int
+
+	// ByErrorType counts the failed inputs by the type of their ParseError.
+	ByErrorType map[ErrorType]int
+}
+
+// batchSlot holds the result of parsing one input. A batch cycles through a
+// fixed set of slots, so PhoneNumbers are reused rather than allocated per
+// input.
+type batchSlot struct {
+	input            string
+	parsed           parsedNumber
+	err              error
+	validationResult ValidationResult
+	numberType       PhoneNumberType
+	done             chan struct{}
+}
+
+// ParseBatch parses a sequence of inputs against defaultRegion across a pool
+// of workers, yielding the results in input order. It is intended for bulk
+// imports, where it is much faster than calling Parse in a loop.
+//
+// To avoid allocating for every input, yielded PhoneNumbers are reused once
+// iteration moves on: callers which keep a number beyond the loop body must
+// copy it (e.g. with proto.Clone). If ctx is cancelled, iteration ends by
+// yielding ctx.Err().
+//
+//	var stats phonenumbers.BatchStats
+//	for num, err := range phonenumbers.ParseBatch(ctx, inputs, "US", phonenumbers.BatchOptions{Stats: &stats}) {
+//		...
+//	}
+func (u *Util) ParseBatch(ctx context.Context, inputs iter.Seq[string], defaultRegion string, opts BatchOptions) iter.Seq2[*PhoneNumber, error] {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	stats := opts.Stats
+
+	return func(yield func(*PhoneNumber, error) bool) {
+		if stats != nil {
+			*stats = BatchStats{
+				ByValidationResult: make(map[ValidationResult]int),
+				ByNumberType:       make(map[PhoneNumberType]int),
+				ByErrorType:        make(map[ErrorType]int),
+			}
+		}
+
+		// a slot is either free, or queued in input order and being parsed
+		numSlots := workers * 2
+		free := make(chan *batchSlot, numSlots)
+		for range numSlots {
+			free <- &batchSlot{done: make(chan struct{}, 1)}
+		}
+		queued := make(chan *batchSlot, numSlots)
+		jobs := make(chan *batchSlot, numSlots)
+		stop := make(chan struct{})
+
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		defer close(stop)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(jobs)
+			defer close(queued)
+
+			for input := range inputs {
+				var slot *batchSlot
+				select {
+				case slot = <-free:
+				case <-stop:
+					return
+				case <-ctx.Done():
+					return
+				}
+				slot.input = input
+				queued <- slot
+				jobs <- slot
+			}
+		}()
+
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for slot := range jobs {
+					slot.clear()
+					slot.err = u.parse(&slot.parsed, slot.input, defaultRegion)
+					if stats != nil && slot.err == nil {
+						slot.validationResult = u.IsPossibleNumberWithReason(&slot.parsed.number)
+						slot.numberType = u.GetNumberType(&slot.parsed.number)
+					}
+					slot.done <- struct{}{}
+				}
+			}()
+		}
+
+		for {
+			var slot *batchSlot
+			select {
+			case slot = <-queued:
+			case <-ctx.Done():
+				yield(nil, ctx.Err())
+				return
+			}
+			if slot == nil {
+				// the producer may have stopped because of cancellation
+				if ctx.Err() != nil {
+					yield(nil, ctx.Err())
+				}
+				return
+			}
+			<-slot.done
+
+			if stats != nil {
+				stats.add(slot)
+			}
+			if slot.err != nil {
+				if !yield(nil, slot.err) {
+					return
+				}
+			} else if !yield(&slot.parsed.number, nil) {
+				return
+			}
+			free <- slot
+		}
+	}
+}
+
+// clear clears the fields of the slot's number which parsing only sets when
+// its input has them. The country code and national number are always set.
+func (s *batchSlot) clear() {
+	s.parsed.number.Extension = nil
+	s.parsed.number.ItalianLeadingZero = nil
+	s.parsed.number.NumberOfLeadingZeros = nil
+}
+
+func (s *BatchStats) add(slot *batchSlot) {
+	if slot.err != nil {
+		s.Failed++
+		var pe *ParseError
+		if errors.As(slot.err, &pe) {
+			s.ByErrorType[pe.Type]++
+		}
+		return
+	}
+	s.Parsed++
+	s.ByValidationResult[slot.validationResult]++
+	s.ByNumberType[slot.numberType]++
+}
@@ -0,0 +1,66 @@
+package phonenumbers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseBatch(t *testing.T) {
+	useTestMetadata(t)
+
+	var inputs []string
+	for i := range 1000 {
+		switch i % 4 {
+		case 0:
+			inputs = append(inputs, fmt.Sprintf("650 253 %04d", i))
+		case 1:
+			inputs = append(inputs, fmt.Sprintf("+6421387%03d", i%1000))
+		case 2:
+			inputs = append(inputs, "not a number")
+		case 3:
+			// extensions mustn't leak into the numbers parsed next in the same slot
+			inputs = append(inputs, fmt.Sprintf("+64 3 331 %04d ext %d", i, i))
+		}
+	}
+
+	var stats BatchStats
+	i := 0
+	for num, err := range ParseBatch(context.Background(), slices.Values(inputs), regionCode.US, BatchOptions{Workers: 3, Stats: &stats}) {
+		expected, expectedErr := Parse(inputs[i], regionCode.US)
+		if expectedErr != nil {
+			assert.Equal(t, expectedErr, err, "error mismatch for input %q", inputs[i])
+			assert.Nil(t, num)
+		} else {
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(expected, num), "number mismatch for input %q", inputs[i])
+		}
+		i++
+	}
+	assert.Equal(t, 1000, i)
+	assert.Equal(t, BatchStats{
+		Parsed:             750,
+		Failed:             250,
+		ByValidationResult: map[ValidationResult]int{IS_POSSIBLE: 750},
+		ByNumberType:       map[PhoneNumberType]int{FIXED_LINE_OR_MOBILE: 250, MOBILE: 250, FIXED_LINE: 250},
+		ByErrorType:        map[ErrorType]int{ErrorType_NOT_A_NUMBER: 250},
+	}, stats)
+
+	// cancelling the context stops the workers
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var lastErr error
+	i = 0
+	for _, err := range ParseBatch(ctx, slices.Values(inputs), regionCode.US, BatchOptions{Workers: 2}) {
+		if i++; i == 10 {
+			cancel()
+		}
+		lastErr = err
+	}
+	assert.Less(t, i, 1000)
+	assert.ErrorIs(t, lastErr, context.Canceled)
+}
@@ -0,0 +1,129 @@
+package phonenumbers
+
+import (
+	"cmp"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ParseCandidate is one interpretation of a number returned by
+// ParseWithCandidateRegions.
+type ParseCandidate struct {
+	// Number is the parsed number.
+	Number *PhoneNumber
+
+	// Regions are the candidate regions, in the order given, which parsed
+	// the input as Number.
+	Regions []string
+
+	// NumberRegion is the region Number belongs to, which can differ from
+	// the candidate regions when the input included a country calling code.
+	NumberRegion string
+
+	// Valid is whether Number is a valid number.
+	Valid bool
+
+	// Type is the type of Number, UNKNOWN if it is not valid.
+	Type PhoneNumberType
+
+	// Reason explains the candidate's place in the ranking.
+	Reason string
+
+	rank candidateRank
+}
+
+// candidateRank orders the interpretations of a number, best first.
+type candidateRank int
+
+const (
+	rankValidInRegion candidateRank = iota
+	rankValidElsewhere
+	rankPossible
+	rankNotPossible
+)
+
+// ParseWithCandidateRegions parses a number written without a country calling
+// code against each of several plausible default regions, e.g. a user's IP,
+// SIM and account countries, in order of preference. It returns the distinct
+// interpretations ranked best first:
+//
+//  1. numbers valid in the candidate region they were parsed against
+//  2. numbers valid in some other region, e.g. because the input carried its
+//     own country calling code
+//  3. numbers that are possible but not valid
+//  4. numbers that aren't even possible
+//
+// Ties are broken by the order of regions. Regions that fail to parse the
+// input are left out; if they all fail, the error from the first is returned.
+func (u *Util) ParseWithCandidateRegions(numberToParse string, regions []string) ([]*ParseCandidate, error) {
+	var (
+		candidates []*ParseCandidate
+		firstErr   error
+	)
+
+	for _, region := range regions {
+		number, err := u.Parse(numberToParse, region)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		// the same number from an earlier region, e.g. when the input has a
+		// country calling code and so parses the same for every region, is
+		// merged into one candidate, which ranks as well as it does for the
+		// best of its regions
+		c := u.rankCandidate(number, region)
+		i := slices.IndexFunc(candidates, func(other *ParseCandidate) bool { return proto.Equal(other.Number, number) })
+		if i >= 0 {
+			prev := candidates[i]
+			if c.rank < prev.rank {
+				prev.rank, prev.Reason = c.rank, c.Reason
+			}
+			prev.Regions = append(prev.Regions, region)
+			continue
+		}
+
+		candidates = append(candidates, c)
+	}
+
+	if len(candidates) == 0 {
+		if firstErr == nil {
+			firstErr = ErrInvalidCountryCode
+		}
+		return nil, firstErr
+	}
+
+	slices.SortStableFunc(candidates, func(a, b *ParseCandidate) int { return cmp.Compare(a.rank, b.rank) })
+	return candidates, nil
+}
+
+func (u *Util) rankCandidate(number *PhoneNumber, region string) *ParseCandidate {
+	c := &ParseCandidate{
+		Number:       number,
+		Regions:      []string{region},
+		NumberRegion: u.GetRegionCodeForNumber(number),
+		Valid:        u.IsValidNumber(number),
+		Type:         UNKNOWN,
+	}
+
+	switch {
+	case c.Valid && c.NumberRegion == region:
+		c.Type = u.GetNumberType(number)
+		c.rank = rankValidInRegion
+		c.Reason = "valid " + numberTypeDescNames[c.Type] + " number in " + region
+	case c.Valid:
+		c.Type = u.GetNumberType(number)
+		c.rank = rankValidElsewhere
+		c.Reason = "valid " + numberTypeDescNames[c.Type] + " number in " + c.NumberRegion + ", not " + region
+	case u.IsPossibleNumber(number):
+		c.rank = rankPossible
+		c.Reason = "possible but not valid number for " + region
+	default:
+		c.rank = rankNotPossible
+		c.Reason = "not a possible number for " + region
+	}
+	return c
+}
@@ -0,0 +1,56 @@
+package phonenumbers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWithCandidateRegions(t *testing.T) {
+	useTestMetadata(t)
+
+	type candidate struct {
+		number  string
+		regions []string
+		valid   bool
+		numType PhoneNumberType
+		reason  string
+	}
+	tests := []struct {
+		input      string
+		regions    []string
+		candidates []candidate
+	}{
+		{"03 331 6005", []string{regionCode.US, regionCode.NZ, regionCode.GB}, []candidate{
+			{"+6433316005", []string{regionCode.NZ}, true, FIXED_LINE, "valid fixedLine number in NZ"},
+			{"+44033316005", []string{regionCode.GB}, false, UNKNOWN, "possible but not valid number for GB"},
+			{"+1033316005", []string{regionCode.US}, false, UNKNOWN, "not a possible number for US"},
+		}},
+		{"242 365 1234", []string{regionCode.US, regionCode.GB, regionCode.BS}, []candidate{
+			{"+12423651234", []string{regionCode.US, regionCode.BS}, true, FIXED_LINE, "valid fixedLine number in BS"},
+			{"+442423651234", []string{regionCode.GB}, true, FIXED_LINE, "valid fixedLine number in GB"},
+		}},
+		{"242 365 1234", []string{regionCode.US}, []candidate{
+			{"+12423651234", []string{regionCode.US}, true, FIXED_LINE, "valid fixedLine number in BS, not US"},
+		}},
+		{"+64 3 331 6005", []string{regionCode.US, regionCode.ZZ}, []candidate{
+			{"+6433316005", []string{regionCode.US, regionCode.ZZ}, true, FIXED_LINE, "valid fixedLine number in NZ, not US"},
+		}},
+	}
+	for _, tc := range tests {
+		candidates, err := ParseWithCandidateRegions(tc.input, tc.regions)
+		assert.NoError(t, err)
+
+		var actual []candidate
+		for _, c := range candidates {
+			actual = append(actual, candidate{Format(c.Number, E164), c.Regions, c.Valid, c.Type, c.Reason})
+		}
+		assert.Equal(t, tc.candidates, actual, "candidates mismatch for input %q in %v", tc.input, tc.regions)
+	}
+
+	_, err := ParseWithCandidateRegions("not a number", []string{regionCode.US, regionCode.NZ})
+	assert.ErrorIs(t, err, ErrNotANumber)
+
+	_, err = ParseWithCandidateRegions("03 331 6005", nil)
+	assert.ErrorIs(t, err, ErrInvalidCountryCode)
+}
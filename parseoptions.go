@@ -0,0 +1,120 @@
+package phonenumbers
+
+import "slices"
+
+// ExtensionPolicy is how ParseWithOptions treats an extension in its input.
+type ExtensionPolicy int
+
+const (
+	// EXTENSION_KEEP keeps the extension on the parsed number, as Parse does.
+	EXTENSION_KEEP ExtensionPolicy = iota
+	// EXTENSION_STRIP drops the extension from the parsed number.
+	EXTENSION_STRIP
+	// EXTENSION_REJECT fails with ErrExtensionNotAllowed if there is one.
+	EXTENSION_REJECT
+)
+
+// AlphaPolicy is how ParseWithOptions treats numbers written with letters,
+// such as "1-800-FLOWERS".
+type AlphaPolicy int
+
+const (
+	// ALPHA_ALLOW converts letters to their keypad digits, as Parse does.
+	ALPHA_ALLOW AlphaPolicy = iota
+	// ALPHA_REJECT fails with ErrAlphaNotAllowed if the number has letters.
+	ALPHA_REJECT
+)
+
+// ParseOptions are the options for ParseWithOptions. The zero value parses
+// exactly like Parse with no default region.
+type ParseOptions struct {
+	// DefaultRegion is the region to assume for numbers not written in
+	// international format.
+	DefaultRegion string
+
+	// KeepRawInput populates the raw_input and country_code_source fields,
+	// as ParseAndKeepRawInput does.
+	KeepRawInput bool
+
+	// Strict fails with ErrInvalidNumber unless the number is valid (see
+	// IsValidNumber).
+	Strict bool
+
+	// AllowedRegions, if not empty, fails with ErrRegionNotAllowed unless the
+	// number is from one of these regions.
+	AllowedRegions []string
+
+	// DeniedRegions fails with ErrRegionNotAllowed if the number is from one
+	// of these regions.
+	DeniedRegions []string
+
+	// AllowedTypes, if not empty, fails with ErrNumberTypeNotAllowed unless
+	// the number is of one of these types. A FIXED_LINE_OR_MOBILE number is
+	// allowed if FIXED_LINE or MOBILE is.
+	AllowedTypes []PhoneNumberType
+
+	// Extensions is how to treat an extension.
+	Extensions ExtensionPolicy
+
+	// AlphaCharacters is how to treat numbers written with letters.
+	AlphaCharacters AlphaPolicy
+}
+
+// ParseWithOptions parses a string into a phone number like Parse, and then
+// checks it against opts. The region of a number for AllowedRegions and
+// DeniedRegions is that of GetRegionCodeForNumber or, for numbers which aren't
+// valid, the main region for their country calling code. If a number parses
+// but breaks one of opts, it is always returned along with the error for that
+// option: ErrAlphaNotAllowed, ErrExtensionNotAllowed, ErrInvalidNumber,
+// ErrRegionNotAllowed or ErrNumberTypeNotAllowed.
+func (u *Util) ParseWithOptions(numberToParse string, opts ParseOptions) (*PhoneNumber, error) {
+	phoneNumber := &PhoneNumber{}
+	var progress parseProgress
+	err := u.parseHelper(numberToParse, opts.DefaultRegion, opts.KeepRawInput, true, phoneNumber, &progress)
+	if err != nil {
+		return phoneNumber, u.parseError(err, numberToParse, opts.DefaultRegion, progress.offset)
+	}
+
+	if opts.AlphaCharacters == ALPHA_REJECT && IsAlphaNumber(extractPossibleNumber(numberToParse)) {
+		return phoneNumber, ErrAlphaNotAllowed
+	}
+
+	if phoneNumber.Extension != nil {
+		switch opts.Extensions {
+		case EXTENSION_STRIP:
+			phoneNumber.Extension = nil
+		case EXTENSION_REJECT:
+			return phoneNumber, ErrExtensionNotAllowed
+		}
+	}
+
+	if opts.Strict && !u.IsValidNumber(phoneNumber) {
+		return phoneNumber, ErrInvalidNumber
+	}
+
+	if len(opts.AllowedRegions) > 0 || len(opts.DeniedRegions) > 0 {
+		region := u.GetRegionCodeForNumber(phoneNumber)
+		if region == "" {
+			region = u.GetRegionCodeForCountryCode(int(phoneNumber.GetCountryCode()))
+		}
+		if len(opts.AllowedRegions) > 0 && !slices.Contains(opts.AllowedRegions, region) {
+			return phoneNumber, ErrRegionNotAllowed
+		}
+		if slices.Contains(opts.DeniedRegions, region) {
+			return phoneNumber, ErrRegionNotAllowed
+		}
+	}
+
+	if len(opts.AllowedTypes) > 0 {
+		numberType := u.GetNumberType(phoneNumber)
+		allowed := slices.Contains(opts.AllowedTypes, numberType)
+		if numberType == FIXED_LINE_OR_MOBILE {
+			allowed = allowed || slices.Contains(opts.AllowedTypes, FIXED_LINE) || slices.Contains(opts.AllowedTypes, MOBILE)
+		}
+		if !allowed {
+			return phoneNumber, ErrNumberTypeNotAllowed
+		}
+	}
+
+	return phoneNumber, nil
+}
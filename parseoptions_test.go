@@ -0,0 +1,69 @@
+package phonenumbers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWithOptions(t *testing.T) {
+	useTestMetadata(t)
+
+	tests := []struct {
+		input    string
+		opts     ParseOptions
+		expected string
+		err      error
+	}{
+		{"650 253 0000", ParseOptions{DefaultRegion: regionCode.US}, "+16502530000", nil},
+		{"650 253 0000", ParseOptions{}, "", ErrInvalidCountryCode},
+
+		// strict
+		{"+1 650 253 000", ParseOptions{}, "+1650253000", nil},
+		{"+1 650 253 000", ParseOptions{Strict: true}, "+1650253000", ErrInvalidNumber},
+
+		// regions
+		{"+1 242 365 1234", ParseOptions{AllowedRegions: []string{regionCode.US, regionCode.BS}}, "+12423651234", nil},
+		{"+1 242 365 1234", ParseOptions{AllowedRegions: []string{regionCode.US}}, "+12423651234", ErrRegionNotAllowed},
+		{"+1 242 365 1234", ParseOptions{DeniedRegions: []string{regionCode.BS}}, "+12423651234", ErrRegionNotAllowed},
+		{"+1 650 253 000", ParseOptions{AllowedRegions: []string{regionCode.US}}, "+1650253000", nil},
+
+		// types
+		{"+64 21 387 835", ParseOptions{AllowedTypes: []PhoneNumberType{MOBILE}}, "+6421387835", nil},
+		{"+64 3 331 6005", ParseOptions{AllowedTypes: []PhoneNumberType{MOBILE}}, "+6433316005", ErrNumberTypeNotAllowed},
+		{"+1 650 253 0000", ParseOptions{AllowedTypes: []PhoneNumberType{MOBILE}}, "+16502530000", nil},
+		{"+1 650 253 0000", ParseOptions{AllowedTypes: []PhoneNumberType{TOLL_FREE}}, "+16502530000", ErrNumberTypeNotAllowed},
+
+		// extensions
+		{"+64 3 331 6005 ext 123", ParseOptions{}, "+6433316005;ext=123", nil},
+		{"+64 3 331 6005 ext 123", ParseOptions{Extensions: EXTENSION_STRIP}, "+6433316005", nil},
+		{"+64 3 331 6005 ext 123", ParseOptions{Extensions: EXTENSION_REJECT}, "+6433316005;ext=123", ErrExtensionNotAllowed},
+		{"+64 3 331 6005", ParseOptions{Extensions: EXTENSION_REJECT}, "+6433316005", nil},
+
+		// alpha characters
+		{"1-800-FLOWERS", ParseOptions{DefaultRegion: regionCode.US}, "+18003569377", nil},
+		{"1-800-FLOWERS", ParseOptions{DefaultRegion: regionCode.US, AlphaCharacters: ALPHA_REJECT}, "+18003569377", ErrAlphaNotAllowed},
+		{"+64 3 331 6005 ext 123", ParseOptions{AlphaCharacters: ALPHA_REJECT}, "+6433316005;ext=123", nil},
+	}
+	for _, tc := range tests {
+		num, err := ParseWithOptions(tc.input, tc.opts)
+		if tc.err != nil {
+			assert.ErrorIs(t, err, tc.err, "error mismatch for input %q with %+v", tc.input, tc.opts)
+		} else {
+			assert.NoError(t, err, "unexpected error for input %q with %+v", tc.input, tc.opts)
+		}
+		// numbers which parse are returned even if they break the options
+		if tc.expected != "" {
+			actual := Format(num, E164)
+			if num.Extension != nil {
+				actual += ";ext=" + num.GetExtension()
+			}
+			assert.Equal(t, tc.expected, actual, "number mismatch for input %q with %+v", tc.input, tc.opts)
+		}
+	}
+
+	num, err := ParseWithOptions("+64 3 331 6005", ParseOptions{KeepRawInput: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "+64 3 331 6005", num.GetRawInput())
+	assert.Equal(t, PhoneNumber_FROM_NUMBER_WITH_PLUS_SIGN, num.GetCountryCodeSource())
+}
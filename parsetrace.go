@@ -0,0 +1,165 @@
+package phonenumbers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseTrace records the steps parsing took to get from its input to a
+// PhoneNumber, to help explain why a number parsed the way it did. See
+// ParseWithTrace.
+type ParseTrace struct {
+	// Steps are the steps taken, in order. If parsing failed, the last step
+	// is the error.
+	Steps []ParseStep
+
+	// NumberType is the type of the parsed number, or UNKNOWN if parsing
+	// failed or the number matched none of its region's number types.
+	NumberType PhoneNumberType
+}
+
+// ParseStep is a single step of a ParseTrace.
+type ParseStep struct {
+	// Stage is the name of the upstream method performing the step, e.g.
+	// "maybeStripNationalPrefixAndCarrierCode".
+	Stage string
+
+	// Result is the number (or other value) the step produced.
+	Result string
+
+	// Detail describes what the step did, if anything.
+	Detail string
+}
+
+// String returns the trace as one line per step.
+func (t *ParseTrace) String() string {
+	var sb strings.Builder
+	for _, s := range t.Steps {
+		fmt.Fprintf(&sb, "%s: %q", s.Stage, s.Result)
+		if s.Detail != "" {
+			fmt.Fprintf(&sb, " (%s)", s.Detail)
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// ParseWithTrace parses a string exactly like Parse, also returning a trace
+// of how the input was interpreted: the possible number extracted from it,
+// any RFC3966 phone-context, extension, international prefix and country
+// calling code, the national prefix and carrier code stripped, and the
+// number type matched. The trace is returned even if parsing fails.
+func (u *Util) ParseWithTrace(numberToParse, defaultRegion string) (*PhoneNumber, *ParseTrace, error) {
+	trace := &ParseTrace{NumberType: UNKNOWN}
+	phoneNumber := &PhoneNumber{}
+
+	progress := parseProgress{trace: trace}
+	err := u.parseHelper(numberToParse, defaultRegion, false, true, phoneNumber, &progress)
+	if err != nil {
+		err = u.parseError(err, numberToParse, defaultRegion, progress.offset)
+		detail := ""
+		if pe, ok := err.(*ParseError); ok {
+			detail = pe.Type.String()
+		}
+		trace.add("error", err.Error(), detail)
+		return phoneNumber, trace, err
+	}
+
+	trace.NumberType = u.GetNumberType(phoneNumber)
+	trace.add("getNumberType", numberTypeDescNames[trace.NumberType], "region "+u.GetRegionCodeForNumber(phoneNumber))
+	return phoneNumber, trace, nil
+}
+
+// numberTypeDescNames are the metadata elements (PhoneNumberDescs) for each
+// number type.
+var numberTypeDescNames = map[PhoneNumberType]string{
+	FIXED_LINE:           "fixedLine",
+	MOBILE:               "mobile",
+	FIXED_LINE_OR_MOBILE: "fixedLine/mobile",
+	TOLL_FREE:            "tollFree",
+	PREMIUM_RATE:         "premiumRate",
+	SHARED_COST:          "sharedCost",
+	VOIP:                 "voip",
+	PERSONAL_NUMBER:      "personalNumber",
+	PAGER:                "pager",
+	UAN:                  "uan",
+	VOICEMAIL:            "voicemail",
+	UNKNOWN:              "unknown",
+}
+
+// add appends a step to the trace. Callers check for a trace first, so that
+// parsing without one doesn't build the step.
+func (t *ParseTrace) add(stage, result, detail string) {
+	t.Steps = append(t.Steps, ParseStep{Stage: stage, Result: result, Detail: detail})
+}
+
+// possibleNumber records the possible number buildNationalNumberForParsing
+// extracted from numberToParse.
+func (t *ParseTrace) possibleNumber(numberToParse, nationalNumber string) {
+	detail := ""
+	if nationalNumber != numberToParse {
+		detail = "stripped text around the number"
+	}
+	t.add("extractPossibleNumber", nationalNumber, detail)
+}
+
+// phoneContext records the national number buildNationalNumberForParsing
+// built from RFC3966 input with the given phone-context.
+func (t *ParseTrace) phoneContext(nationalNumber, phoneContext string, valid bool) {
+	detail := fmt.Sprintf("RFC3966 phone-context %q", phoneContext)
+	if !valid {
+		detail += " is invalid"
+	} else if phoneContext[0] == plusSign {
+		detail += " used as prefix"
+	} else {
+		detail += " is a domain, ignored"
+	}
+	t.add("buildNationalNumberForParsing", nationalNumber, detail)
+}
+
+// internationalPrefix records the prefix maybeExtractCountryCode stripped
+// from a number, leaving number.
+func (t *ParseTrace) internationalPrefix(number string, countryCodeSource PhoneNumber_CountryCodeSource, prefix string) {
+	switch countryCodeSource {
+	case PhoneNumber_FROM_NUMBER_WITH_PLUS_SIGN:
+		t.add("maybeStripInternationalPrefixAndNormalize", number, "stripped plus sign")
+	case PhoneNumber_FROM_NUMBER_WITH_IDD:
+		t.add("maybeStripInternationalPrefixAndNormalize", number, fmt.Sprintf("stripped international prefix %q", prefix))
+	default:
+		t.add("maybeStripInternationalPrefixAndNormalize", number, "no international prefix")
+	}
+}
+
+// countryCode records the country calling code maybeExtractCountryCode
+// found, if any.
+func (t *ParseTrace) countryCode(countryCode int, countryCodeSource PhoneNumber_CountryCodeSource) {
+	if countryCode == 0 {
+		t.add("maybeExtractCountryCode", "", "no country calling code, using default region")
+		return
+	}
+	t.add("maybeExtractCountryCode", fmt.Sprint(countryCode), countryCodeSource.String())
+}
+
+// nationalPrefix records what maybeStripNationalPrefixAndCarrierCode did to
+// number, and whether parseHelper kept the result.
+func (t *ParseTrace) nationalPrefix(number, stripped, carrierCode string, validationResult ValidationResult) {
+	if stripped == number {
+		t.add("maybeStripNationalPrefixAndCarrierCode", number, "no national prefix")
+		return
+	}
+
+	var details []string
+	if strings.HasSuffix(number, stripped) {
+		details = append(details, fmt.Sprintf("stripped national prefix %q", number[:len(number)-len(stripped)]))
+	} else {
+		details = append(details, "applied national prefix transform rule")
+	}
+	if carrierCode != "" {
+		details = append(details, fmt.Sprintf("carrier code %q", carrierCode))
+	}
+	if validationResult == TOO_SHORT || validationResult == IS_POSSIBLE_LOCAL_ONLY || validationResult == INVALID_LENGTH {
+		details = append(details, "discarded as the remaining number has an impossible length")
+		stripped = number
+	}
+	t.add("maybeStripNationalPrefixAndCarrierCode", stripped, strings.Join(details, ", "))
+}
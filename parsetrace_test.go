@@ -0,0 +1,73 @@
+package phonenumbers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseWithTrace(t *testing.T) {
+	useTestMetadata(t)
+
+	tests := []struct {
+		input    string
+		region   string
+		expected []ParseStep
+	}{
+		{"Tel: 011 64 3 331 6005 ext 3456", regionCode.US, []ParseStep{
+			{"extractPossibleNumber", "011 64 3 331 6005 ext 3456", "stripped text around the number"},
+			{"maybeStripExtension", "011 64 3 331 6005", `extension "3456"`},
+			{"maybeStripInternationalPrefixAndNormalize", "6433316005", `stripped international prefix "011"`},
+			{"maybeExtractCountryCode", "64", "FROM_NUMBER_WITH_IDD"},
+			{"maybeStripNationalPrefixAndCarrierCode", "33316005", "no national prefix"},
+			{"getNumberType", "fixedLine", "region NZ"},
+		}},
+		{"tel:331-6005;phone-context=+64-3", regionCode.US, []ParseStep{
+			{"buildNationalNumberForParsing", "+64-3331-6005", `RFC3966 phone-context "+64-3" used as prefix`},
+			{"maybeStripInternationalPrefixAndNormalize", "6433316005", "stripped plus sign"},
+			{"maybeExtractCountryCode", "64", "FROM_NUMBER_WITH_PLUS_SIGN"},
+			{"maybeStripNationalPrefixAndCarrierCode", "33316005", "no national prefix"},
+			{"getNumberType", "fixedLine", "region NZ"},
+		}},
+		{"03 331 6005", regionCode.NZ, []ParseStep{
+			{"extractPossibleNumber", "03 331 6005", ""},
+			{"maybeStripInternationalPrefixAndNormalize", "033316005", "no international prefix"},
+			{"maybeExtractCountryCode", "", "no country calling code, using default region"},
+			{"maybeStripNationalPrefixAndCarrierCode", "33316005", `stripped national prefix "0"`},
+			{"getNumberType", "fixedLine", "region NZ"},
+		}},
+		{"0044", regionCode.GB, []ParseStep{
+			{"extractPossibleNumber", "0044", ""},
+			{"maybeStripInternationalPrefixAndNormalize", "44", `stripped international prefix "00"`},
+			{"error", ErrTooShortAfterIDD.Error(), "TOO_SHORT_AFTER_IDD"},
+		}},
+	}
+	for _, tc := range tests {
+		num, trace, err := ParseWithTrace(tc.input, tc.region)
+		assert.Equal(t, tc.expected, trace.Steps, "trace mismatch for input %q", tc.input)
+
+		expected, expectedErr := Parse(tc.input, tc.region)
+		assert.Equal(t, expectedErr, err)
+		if err == nil {
+			assert.True(t, proto.Equal(expected, num))
+			assert.Equal(t, FIXED_LINE, trace.NumberType)
+		}
+	}
+}
+
+func TestParseWithoutTraceAllocations(t *testing.T) {
+	// tracing mustn't cost anything when parsing without a trace, so these are
+	// the allocations of parsing before tracing was added
+	tests := []struct {
+		input  string
+		allocs float64
+	}{
+		{"+44 20 8366 1177", 22},
+		{"020 8366 1177", 29},
+		{"Tel: (020) 8366-1177 ext. 123", 33},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.allocs, testing.AllocsPerRun(100, func() { Parse(tc.input, regionCode.GB) }), "allocations for input %q", tc.input)
+	}
+}
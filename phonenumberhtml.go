@@ -0,0 +1,249 @@
+package phonenumbers
+
+import (
+	"html"
+	"iter"
+	"strings"
+)
+
+// inlineHTMLElements are the elements which don't break up the text around
+// them, so that a number can be split across them, e.g. "<b>650</b> 253 0000".
+// Any other element ends a number.
+var inlineHTMLElements = map[string]bool{
+	"a": true, "abbr": true, "b": true, "bdi": true, "bdo": true, "cite": true, "code": true,
+	"data": true, "dfn": true, "em": true, "font": true, "i": true, "kbd": true, "mark": true,
+	"nobr": true, "q": true, "s": true, "samp": true, "small": true, "span": true, "strike": true,
+	"strong": true, "sub": true, "sup": true, "time": true, "tt": true, "u": true, "var": true,
+	"wbr": true,
+}
+
+// rawTextHTMLElements are the elements whose contents aren't visible text.
+var rawTextHTMLElements = map[string]bool{"script": true, "style": true, "template": true, "noscript": true}
+
+// FindNumbersInHTML is FindNumbersWithLeniency for an HTML document. Numbers
+// are only found in the document's visible text: not in tags and their
+// attributes, comments, or the contents of script and style elements. Entities
+// are decoded, whitespace is collapsed as a browser would, and inline elements
+// such as <b> and <span> are seen through, so that "<b>650</b> 253 0000" is
+// found, while block elements and line breaks separate numbers.
+//
+// The start and end of each match are byte offsets into doc, and its raw
+// string is the markup between them, which may include tags and entities.
+// If MatchOptions.Offsets is set, their rune and UTF-16 offsets are into doc
+// too, while MatchOptions.Confidence judges matches by the visible text
+// around them.
+func (u *Util) FindNumbersInHTML(doc, defaultRegion string, leniency Leniency, maxTries int, opts ...MatchOptions) iter.Seq[*PhoneNumberMatch] {
+	return func(yield func(*PhoneNumberMatch) bool) {
+		var o MatchOptions
+		if len(opts) > 0 {
+			o = opts[0]
+		}
+		var cursor *textCursor
+		if o.Offsets {
+			cursor = &textCursor{text: doc}
+			o.Offsets = false
+		}
+
+		visible := extractHTMLText(doc)
+		for m := range u.FindNumbersWithLeniency(visible.String(), defaultRegion, leniency, maxTries, o) {
+			start, end := visible.sourceRange(m.Start(), m.End())
+			match := newPhoneNumberMatch(start, doc[start:end], m.Number())
+			match.region = m.region
+			match.countryCodeSource = m.countryCodeSource
+			match.confidence = m.confidence
+			if cursor != nil {
+				cursor.setOffsets(match)
+			}
+			if !yield(match) {
+				return
+			}
+		}
+	}
+}
+
+// htmlText is the visible text of an HTML document, along with the range of
+// the document each byte of it came from.
+type htmlText struct {
+	text             strings.Builder
+	sourceStarts     []int
+	sourceEnds       []int
+	pendingSpace     bool
+	pendingSpaceFrom int
+
+	// tags are the start and end tags in the document, in order.
+	tags []htmlTag
+}
+
+// htmlTag is a start or end tag in an HTML document.
+type htmlTag struct {
+	start, end int
+	name       string
+	isEnd      bool
+}
+
+func (t *htmlText) String() string { return t.text.String() }
+
+// sourceRange returns the range of the document the visible text from start
+// to end came from.
+func (t *htmlText) sourceRange(start, end int) (int, int) {
+	return t.sourceStarts[start], t.sourceEnds[end-1]
+}
+
+// write appends s to the visible text, as having come from the document
+// between start and end.
+func (t *htmlText) write(s string, start, end int) {
+	if t.pendingSpace {
+		t.pendingSpace = false
+		t.write(" ", t.pendingSpaceFrom, t.pendingSpaceFrom+1)
+	}
+	t.text.WriteString(s)
+	for range len(s) {
+		t.sourceStarts = append(t.sourceStarts, start)
+		t.sourceEnds = append(t.sourceEnds, end)
+	}
+}
+
+// space appends whitespace from the document at offset, collapsing it with
+// any whitespace before it.
+func (t *htmlText) space(offset int) {
+	if !t.pendingSpace && t.text.Len() > 0 && !strings.HasSuffix(t.text.String(), "\n") {
+		t.pendingSpace, t.pendingSpaceFrom = true, offset
+	}
+}
+
+// lineBreak appends a line break for an element from the document between
+// start and end, which separates the text either side.
+func (t *htmlText) lineBreak(start, end int) {
+	t.pendingSpace = false
+	if t.text.Len() > 0 && !strings.HasSuffix(t.text.String(), "\n") {
+		t.write("\n", start, end)
+	}
+}
+
+// extractHTMLText returns the visible text of doc. It is a forgiving scanner
+// rather than a full HTML parser, but handles the markup found in emails and
+// web pages: a '<' that doesn't start markup is treated as text, and
+// unterminated markup runs to the end of the document.
+func extractHTMLText(doc string) *htmlText {
+	t := &htmlText{}
+	i := 0
+	for i < len(doc) {
+		c := doc[i]
+		switch {
+		case c == '<':
+			next, name, isEnd := scanHTMLTag(doc, i)
+			if next < 0 {
+				t.write("<", i, i+1)
+				i++
+				continue
+			}
+			if name != "" {
+				t.tags = append(t.tags, htmlTag{start: i, end: next, name: name, isEnd: isEnd})
+				if !inlineHTMLElements[name] {
+					t.lineBreak(i, next)
+				}
+			}
+			if rawTextHTMLElements[name] && !isEnd {
+				next = skipHTMLRawText(doc, next, name)
+			}
+			i = next
+		case c == '&':
+			decoded, next := decodeHTMLEntity(doc, i)
+			t.write(decoded, i, next)
+			i = next
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			t.space(i)
+			i++
+		default:
+			t.write(doc[i:i+1], i, i+1)
+			i++
+		}
+	}
+	return t
+}
+
+// scanHTMLTag scans the tag, comment or other markup starting at the '<' at
+// start, returning the offset after it, the lowercased name of the element if
+// it is a start or end tag, and whether it is an end tag. The offset is -1 if
+// the '<' doesn't start markup.
+func scanHTMLTag(doc string, start int) (next int, name string, isEnd bool) {
+	i := start + 1
+	if strings.HasPrefix(doc[i:], "!--") {
+		if end := strings.Index(doc[i+3:], "-->"); end >= 0 {
+			return i + 3 + end + 3, "", false
+		}
+		return len(doc), "", false
+	}
+	if i < len(doc) && (doc[i] == '!' || doc[i] == '?') {
+		if end := strings.IndexByte(doc[i:], '>'); end >= 0 {
+			return i + end + 1, "", false
+		}
+		return len(doc), "", false
+	}
+	if i < len(doc) && doc[i] == '/' {
+		isEnd = true
+		i++
+	}
+	nameStart := i
+	for i < len(doc) && isASCIILetterOrDigit(doc[i]) {
+		i++
+	}
+	if i == nameStart || !isASCIILetter(doc[nameStart]) {
+		return -1, "", false
+	}
+	name = strings.ToLower(doc[nameStart:i])
+
+	// skip the attributes, whose values may be quoted and contain '>'
+	var quote byte
+	for ; i < len(doc); i++ {
+		switch c := doc[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i + 1, name, isEnd
+		}
+	}
+	return len(doc), name, isEnd
+}
+
+// skipHTMLRawText returns the offset after the end tag of the raw text
+// element name whose contents start at start.
+func skipHTMLRawText(doc string, start int, name string) int {
+	endTag := "</" + name
+	for i := start; i < len(doc); i++ {
+		if doc[i] == '<' && len(doc)-i >= len(endTag) && strings.EqualFold(doc[i:i+len(endTag)], endTag) {
+			if next, _, _ := scanHTMLTag(doc, i); next >= 0 {
+				return next
+			}
+		}
+	}
+	return len(doc)
+}
+
+// decodeHTMLEntity decodes the character reference starting at the '&' at
+// start, returning it and the offset after it. Anything that isn't a known
+// reference is returned as a literal '&'.
+func decodeHTMLEntity(doc string, start int) (string, int) {
+	// the longest named references, e.g. "&CounterClockwiseContourIntegral;",
+	// are 33 bytes
+	end := strings.IndexByte(doc[start:min(start+40, len(doc))], ';')
+	if end > 1 {
+		ref := doc[start : start+end+1]
+		if decoded := html.UnescapeString(ref); decoded != ref {
+			return decoded, start + end + 1
+		}
+	}
+	return "&", start + 1
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isASCIILetterOrDigit(c byte) bool {
+	return isASCIILetter(c) || (c >= '0' && c <= '9')
+}
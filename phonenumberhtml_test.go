@@ -0,0 +1,49 @@
+package phonenumbers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindNumbersInHTML(t *testing.T) {
+	useTestMetadata(t)
+
+	doc := `<!DOCTYPE html>
+<html>
+<head>
+  <title>Contact</title>
+  <style>.tel::after { content: "650 253 0001"; }</style>
+  <script type="text/javascript">var tel = "650 253 0002"; if (a < b) {}</script>
+</head>
+<body>
+  <!-- old number: 650 253 0003 -->
+  <p data-tel="650 253 0004" title='a > b'>Call <b>650</b> 253 0000 today</p>
+  <p>Or
+    +64&nbsp;3&nbsp;331&nbsp;6005</p>
+  <div>650 253</div><div>0005</div>
+  <p>650 253<br>0006 &amp; 1 &lt; 2</p>
+</body>
+</html>`
+
+	var found []string
+	for m := range FindNumbersInHTML(doc, regionCode.US, VALID, math.MaxInt) {
+		assert.Equal(t, doc[m.Start():m.End()], m.RawString())
+		found = append(found, m.RawString()+" => "+Format(m.Number(), E164))
+	}
+	assert.Equal(t, []string{
+		"650</b> 253 0000 => +16502530000",
+		"+64&nbsp;3&nbsp;331&nbsp;6005 => +6433316005",
+	}, found)
+
+	visible := extractHTMLText(doc)
+	assert.Equal(t, "Contact\nCall 650 253 0000 today\nOr +64 3 331 6005\n650 253\n0005\n650 253\n0006 & 1 < 2\n", visible.String())
+
+	// a '<' that doesn't start markup is text, and unterminated markup runs
+	// to the end of the document
+	assert.Equal(t, "1 < 2, a", extractHTMLText("1 < 2, a <b").String())
+	assert.Equal(t, "x", extractHTMLText("x <!-- unterminated").String())
+	assert.Equal(t, "x\n", extractHTMLText("x<script>650 253 0000").String())
+	assert.Equal(t, "&bogus; &", extractHTMLText("&bogus; &").String())
+}
@@ -1,7 +1,10 @@
 // Port of java/libphonenumber/src/com/google/i18n/phonenumbers/PhoneNumberMatch.java.
 package phonenumbers
 
-import "fmt"
+import (
+	"fmt"
+	"unicode/utf8"
+)
 
 // PhoneNumberMatch is the immutable match of a phone number within a piece of
 // text. Matches may be found using FindNumbers.
@@ -11,16 +14,30 @@ import "fmt"
 // RawString to obtain the matched substring. Note that, unlike upstream's Java
 // (which uses UTF-16 char offsets), Start and End are byte offsets into the
 // searched string, so text[match.Start():match.End()] == match.RawString().
+// Matches found with MatchOptions.Offsets also have their offsets in runes
+// and in UTF-16 code units, as used by JavaScript, and those found with
+// MatchOptions.Confidence have a confidence score.
 type PhoneNumberMatch struct {
 	start     int
 	rawString string
 	number    *PhoneNumber
+
+	runeStart, runeEnd   int
+	utf16Start, utf16End int
+
+	confidence *MatchConfidence
+
+	region string
+
+	// countryCodeSource is how the country calling code of number was found,
+	// which number itself doesn't keep.
+	countryCodeSource PhoneNumber_CountryCodeSource
 }
 
 // newPhoneNumberMatch creates a new match. start is the byte offset into the
 // target text, rawString the matched substring, and number the parsed number.
 func newPhoneNumberMatch(start int, rawString string, number *PhoneNumber) *PhoneNumberMatch {
-	return &PhoneNumberMatch{start: start, rawString: rawString, number: number}
+	return &PhoneNumberMatch{start: start, rawString: rawString, number: number, runeStart: -1, runeEnd: -1, utf16Start: -1, utf16End: -1}
 }
 
 // Number returns the phone number matched by the receiver.
@@ -32,6 +49,36 @@ func (m *PhoneNumberMatch) Start() int { return m.start }
 // End returns the exclusive end byte offset of the matched phone number within the searched text.
 func (m *PhoneNumberMatch) End() int { return m.start + len(m.rawString) }
 
+// RuneStart returns the start rune offset of the matched phone number within
+// the searched text, or -1 if the match wasn't found with
+// MatchOptions.Offsets.
+func (m *PhoneNumberMatch) RuneStart() int { return m.runeStart }
+
+// RuneEnd returns the exclusive end rune offset of the matched phone number
+// within the searched text, or -1 if the match wasn't found with
+// MatchOptions.Offsets.
+func (m *PhoneNumberMatch) RuneEnd() int { return m.runeEnd }
+
+// UTF16Start returns the start UTF-16 code unit offset of the matched phone
+// number within the searched text, or -1 if the match wasn't found with
+// MatchOptions.Offsets.
+func (m *PhoneNumberMatch) UTF16Start() int { return m.utf16Start }
+
+// UTF16End returns the exclusive end UTF-16 code unit offset of the matched
+// phone number within the searched text, or -1 if the match wasn't found with
+// MatchOptions.Offsets.
+func (m *PhoneNumberMatch) UTF16End() int { return m.utf16End }
+
+// Region returns the region of the number: if it was written with its country
+// calling code, the region GetRegionCodeForNumber gives for it, and otherwise
+// the region assumed for it, i.e. the default region given to FindNumbers, or
+// the one chosen from MatchOptions.Regions.
+func (m *PhoneNumberMatch) Region() string { return m.region }
+
+// Confidence returns how likely the match is to really be a phone number, or
+// nil if the match wasn't found with MatchOptions.Confidence.
+func (m *PhoneNumberMatch) Confidence() *MatchConfidence { return m.confidence }
+
 // RawString returns the raw substring matched as a phone number in the searched text.
 func (m *PhoneNumberMatch) RawString() string { return m.rawString }
 
@@ -39,3 +86,33 @@ func (m *PhoneNumberMatch) RawString() string { return m.rawString }
 func (m *PhoneNumberMatch) String() string {
 	return fmt.Sprintf("PhoneNumberMatch [%d,%d) %s", m.Start(), m.End(), m.rawString)
 }
+
+// textCursor converts byte offsets in a text to rune and UTF-16 offsets. It
+// walks forward from the last offset it converted, so converting the
+// increasing offsets of the matches in a text walks the text just once.
+type textCursor struct {
+	text                string
+	bytes, runes, utf16 int
+}
+
+// setOffsets sets the rune and UTF-16 offsets of match, which must not start
+// before the end of the previous match the cursor was used for.
+func (c *textCursor) setOffsets(match *PhoneNumberMatch) {
+	match.runeStart, match.utf16Start = c.advance(match.Start())
+	match.runeEnd, match.utf16End = c.advance(match.End())
+}
+
+func (c *textCursor) advance(offset int) (runes, utf16 int) {
+	for c.bytes < offset {
+		r, size := utf8.DecodeRuneInString(c.text[c.bytes:])
+		c.bytes += size
+		c.runes++
+		if r >= 0x10000 {
+			// encoded as a surrogate pair
+			c.utf16 += 2
+		} else {
+			c.utf16++
+		}
+	}
+	return c.runes, c.utf16
+}
@@ -0,0 +1,77 @@
+package phonenumbers
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchOffsets(t *testing.T) {
+	useTestMetadata(t)
+
+	text := "Café ☎️ 650 253 0000 😀😀 or ６５０ ２５３ ００００, \xff then +64 3 331 6005 🎉"
+	offsets := MatchOptions{Offsets: true}
+
+	matches := slices.Collect(FindNumbersWithLeniency(text, regionCode.US, VALID, math.MaxInt, offsets))
+	require.Len(t, matches, 3)
+	for _, m := range matches {
+		assertOffsets(t, text, m)
+	}
+	assert.Equal(t, 8, matches[0].RuneStart())
+	assert.Equal(t, 8, matches[0].UTF16Start())
+	assert.Equal(t, 27, matches[1].RuneStart())
+	assert.Equal(t, 29, matches[1].UTF16Start())
+
+	// other matches don't have them
+	for m := range FindNumbers(text, regionCode.US) {
+		assert.Equal(t, -1, m.RuneStart())
+		assert.Equal(t, -1, m.UTF16End())
+	}
+
+	// HTML matches have offsets into the document
+	doc := "<p title=\"☎️\">Café 😀 <b>650</b> 253 0000</p>"
+	inHTML := slices.Collect(FindNumbersInHTML(doc, regionCode.US, VALID, math.MaxInt, offsets))
+	require.Len(t, inHTML, 1)
+	assertOffsets(t, doc, inHTML[0])
+
+	// and matches read from a stream into the stream, across windows
+	long := strings.Repeat("😀 Café ", streamWindowSize/5) + text
+	var inReader []*PhoneNumberMatch
+	for m, err := range FindNumbersInReader(iotest.HalfReader(strings.NewReader(long)), regionCode.US, VALID, math.MaxInt, offsets) {
+		require.NoError(t, err)
+		inReader = append(inReader, m)
+	}
+	require.Len(t, inReader, 3)
+	for _, m := range inReader {
+		assertOffsets(t, long, m)
+	}
+
+	// as do those found by a Finder
+	finder := NewFinder(regionCode.US, VALID, FinderOptions{Match: offsets})
+	for result, err := range finder.Find(context.Background(), slices.Values([]string{text})) {
+		require.NoError(t, err)
+		require.Len(t, result.Matches, 3)
+		for _, m := range result.Matches {
+			assertOffsets(t, text, m)
+		}
+	}
+}
+
+// assertOffsets asserts that the rune and UTF-16 offsets of m, found in text,
+// match its byte offsets.
+func assertOffsets(t *testing.T, text string, m *PhoneNumberMatch) {
+	t.Helper()
+	assert.Equal(t, utf8.RuneCountInString(text[:m.Start()]), m.RuneStart())
+	assert.Equal(t, utf8.RuneCountInString(text[:m.End()]), m.RuneEnd())
+	assert.Equal(t, len(utf16.Encode([]rune(text[:m.Start()]))), m.UTF16Start())
+	assert.Equal(t, len(utf16.Encode([]rune(text[:m.End()]))), m.UTF16End())
+	assert.Equal(t, m.RawString(), string([]rune(text)[m.RuneStart():m.RuneEnd()]))
+}
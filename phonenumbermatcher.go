@@ -2,9 +2,11 @@
 package phonenumbers
 
 import (
+	"context"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"unicode"
 	"unicode/utf8"
 
@@ -47,6 +49,15 @@ var (
 	// be at the start of a phone number — brackets and plus signs.
 	leadClassPattern *regexp.Regexp
 
+	// vanityNumberPattern matches (at the start of a candidate) a vanity
+	// number, i.e. phoneNumberMatcherPattern but with blocks of letters after
+	// the first block of digits, e.g. "1-800-FLOWERS".
+	vanityNumberPattern *regexp.Regexp
+
+	// lastVanityBlockPattern matches the last block of a vanity number,
+	// along with the punctuation before it.
+	lastVanityBlockPattern = regexp.MustCompile(`[^\p{Nd}A-Za-z]+[\p{Nd}A-Za-z]+$`)
+
 	// innerMatches are patterns used to extract phone numbers from a larger
 	// phone-number-like pattern, ordered by specificity (white-space last).
 	innerMatches = []*regexp.Regexp{
@@ -113,6 +124,78 @@ func init() {
 		"(?i)(?:" + leadClass + punctuation + ")" + leadLimit +
 			digitSequence + "(?:" + punctuation + digitSequence + ")" + blockLimit +
 			"(?:" + extnPatternsForMatching + ")?")
+
+	vanityNumberPattern = regexp.MustCompile(
+		"^(?:" + leadClass + punctuation + ")" + leadLimit +
+			digitSequence + "(?:" + punctuation + "[\\p{Nd}A-Za-z]+)" + blockLimit)
+}
+
+// MatchOptions are options for finding numbers in text, which may be given to
+// FindNumbersWithLeniency and its variants. Only the first MatchOptions given
+// is used.
+type MatchOptions struct {
+	// Vanity is whether to also find vanity numbers, written with the letters
+	// on a keypad, such as "1-800-FLOWERS" or "+44 800 microsoft". Letters
+	// must follow the first block of digits, and there must be at least three
+	// of them. The Number of a vanity match has its letters converted to
+	// digits, without any which are beyond the length of the number and so
+	// not dialled, and keeps the match as its raw input, so that
+	// FormatOutOfCountryKeepingAlphaChars can format it. Leniency levels check
+	// the grouping of the digits the letters stand for, so vanity numbers are
+	// rarely grouped exactly enough for EXACT_GROUPING. A vanity number and
+	// the digits it starts with count once towards maxTries, and once in
+	// FinderStats.Candidates.
+	Vanity bool
+
+	// Regions are more regions to assume for numbers not written in
+	// international format, for text mixing numbers from several regions.
+	// Each candidate is parsed against the default region and then each of
+	// Regions in turn, and the first region giving a number the leniency (or
+	// verifier) accepts wins, as reported by PhoneNumberMatch.Region.
+	// Candidates count once towards maxTries, however many regions they are
+	// tried against.
+	Regions []string
+
+	// Offsets is whether matches also have their offsets in runes and UTF-16
+	// code units (see PhoneNumberMatch.RuneStart and UTF16Start), for use
+	// with other languages' string indexing. They are computed as the text is
+	// scanned, which costs a single extra pass over it.
+	Offsets bool
+
+	// Confidence is whether matches also have a MatchConfidence (see
+	// PhoneNumberMatch.Confidence) based on the match and the text around it,
+	// e.g. so that an importer can accept confident matches and queue the
+	// rest for review:
+	//
+	//	opts := phonenumbers.MatchOptions{Confidence: true}
+	//	for m := range phonenumbers.FindNumbersWithLeniency(text, "US", phonenumbers.VALID, 100, opts) {
+	//		if m.Confidence().Score >= 0.8 {
+	//			...
+	//		}
+	//	}
+	Confidence bool
+}
+
+// setOptions applies the first of opts, if any, to the matcher.
+func (m *phoneNumberMatcher) setOptions(opts []MatchOptions) {
+	if len(opts) == 0 {
+		return
+	}
+	m.vanity = opts[0].Vanity
+	m.regions = append(m.regions[:1:1], opts[0].Regions...)
+	if opts[0].Offsets {
+		m.cursor = &textCursor{text: m.text}
+	}
+	m.confidence = opts[0].Confidence
+}
+
+// matchRegion returns the region of a number parsed assuming region, which is
+// region itself unless the number was written with its country calling code.
+func (m *phoneNumberMatcher) matchRegion(number *PhoneNumber, region string) string {
+	if number.GetCountryCodeSource() == PhoneNumber_FROM_DEFAULT_COUNTRY {
+		return region
+	}
+	return m.util.GetRegionCodeForNumber(number)
 }
 
 // matcherState is the iteration tristate of a phoneNumberMatcher.
@@ -126,59 +209,132 @@ const (
 
 // phoneNumberMatcher finds and extracts telephone numbers from text. It is the
 // engine behind FindNumbers and is not safe for concurrent use. Vanity numbers
-// (using alphabetic digits) are not found.
+// (using alphabetic digits) are only found if vanity is set.
 type phoneNumberMatcher struct {
+	// util is the Util used to parse and verify candidates.
+	util *Util
 	// text is the searched text.
 	text string
-	// preferredRegion is the region to assume for numbers without an
-	// international prefix; may be empty / "ZZ".
-	preferredRegion string
-	// leniency is the degree of validation requested.
-	leniency Leniency
+	// regions are the regions to assume for numbers without an international
+	// prefix, tried in order; may be empty / "ZZ".
+	regions []string
+	// verifier decides which numbers are matches, e.g. a Leniency.
+	verifier Verifier
 	// maxTries is the maximum number of invalid numbers to try before giving up.
 	maxTries int
 
 	state       matcherState
 	lastMatch   *PhoneNumberMatch
 	searchIndex int
+
+	// limit, if not zero, is the offset past which find stops trusting
+	// candidates, because text is a window onto a stream and a candidate
+	// reaching it might continue beyond the window. When find stops at the
+	// limit, limitReached is set and limitCandidate is the candidate's start.
+	limit          int
+	limitReached   bool
+	limitCandidate int
+
+	// cursor, if not nil, sets the rune and UTF-16 offsets of matches.
+	cursor *textCursor
+
+	// ctx, if not nil, stops find once done, as does budget, if not nil, once
+	// it runs out. budget is a count of tries shared with other matchers.
+	// When find stops early for either, interrupted is set.
+	ctx         context.Context
+	budget      *atomic.Int64
+	interrupted bool
+
+	// stats, if not nil, is updated with each candidate verified.
+	stats *FinderStats
+
+	// vanity is whether vanity numbers such as "1-800-FLOWERS" are found.
+	vanity bool
+
+	// confidence is whether matches are given a MatchConfidence.
+	confidence bool
 }
 
-// newPhoneNumberMatcher creates a matcher over text. country is the region to
-// assume for numbers not in international format (empty / "ZZ" if only numbers
-// with a leading plus should be considered). maxTries is clamped to >= 0.
-func newPhoneNumberMatcher(text, country string, leniency Leniency, maxTries int) *phoneNumberMatcher {
+// newPhoneNumberMatcher creates a matcher over text using util. country is the
+// region to assume for numbers not in international format (empty / "ZZ" if only
+// numbers with a leading plus should be considered). maxTries is clamped to >= 0.
+func newPhoneNumberMatcher(util *Util, text, country string, verifier Verifier, maxTries int) *phoneNumberMatcher {
 	if maxTries < 0 {
 		maxTries = 0
 	}
 	return &phoneNumberMatcher{
-		text:            text,
-		preferredRegion: country,
-		leniency:        leniency,
-		maxTries:        maxTries,
-		state:           matcherNotReady,
+		util:     util,
+		text:     text,
+		regions:  []string{country},
+		verifier: verifier,
+		maxTries: maxTries,
+		state:    matcherNotReady,
+	}
+}
+
+// canTry reports whether the matcher may try another candidate.
+func (m *phoneNumberMatcher) canTry() bool {
+	if m.maxTries <= 0 {
+		return false
+	}
+	if (m.ctx != nil && m.ctx.Err() != nil) || (m.budget != nil && m.budget.Load() <= 0) {
+		m.interrupted = true
+		return false
+	}
+	return true
+}
+
+// failedTry records a candidate which wasn't a match.
+func (m *phoneNumberMatcher) failedTry() {
+	m.maxTries--
+	if m.budget != nil {
+		m.budget.Add(-1)
 	}
 }
 
 // find attempts to find the next substring at or after index that represents a
 // phone number, returning the match or nil if none was found.
 func (m *phoneNumberMatcher) find(index int) *PhoneNumberMatch {
-	for m.maxTries > 0 {
+	for m.canTry() {
 		loc := phoneNumberMatcherPattern.FindStringIndex(m.text[index:])
 		if loc == nil {
 			break
 		}
 		start := index + loc[0]
+		if m.limit > 0 && index+loc[1] > m.limit {
+			m.limitReached, m.limitCandidate = true, start
+			break
+		}
 		candidate := m.text[start : index+loc[1]]
 
 		// Check for extra numbers at the end.
 		candidate = trimAfterFirstMatch(secondNumberStartPattern, candidate)
 
+		// Try a vanity number starting here first, as its digits alone would
+		// be a shorter candidate. Both are a single try and a single
+		// candidate, so the vanity number is only counted if it matches, and
+		// otherwise the digits are counted instead.
+		if m.vanity {
+			if vanity := vanityNumberPattern.FindString(m.text[start:]); len(vanity) > len(candidate) && countVanityLetters(vanity) >= 3 {
+				stats := m.stats
+				m.stats = nil
+				match := m.extractVanityMatch(vanity, start)
+				m.stats = stats
+				if match != nil {
+					if m.stats != nil {
+						m.stats.Candidates++
+					}
+					return match
+				}
+			}
+		}
+
 		if match := m.extractMatch(candidate, start); match != nil {
 			return match
 		}
 
 		index = start + len(candidate)
-		m.maxTries--
+		m.failedTry()
 	}
 	return nil
 }
@@ -235,13 +391,47 @@ func (m *phoneNumberMatcher) extractMatch(candidate string, offset int) *PhoneNu
 	return m.extractInnerMatch(candidate, offset)
 }
 
+// extractVanityMatch attempts to extract a match from a vanity candidate at
+// offset, dropping blocks from its end, e.g. a word following the number,
+// while it still has enough letters to be a vanity number.
+func (m *phoneNumberMatcher) extractVanityMatch(candidate string, offset int) *PhoneNumberMatch {
+	for countVanityLetters(candidate) >= 3 {
+		if match := m.parseAndVerifyVanity(candidate, offset); match != nil {
+			return match
+		}
+		loc := lastVanityBlockPattern.FindStringIndex(candidate)
+		if loc == nil {
+			break
+		}
+		candidate = candidate[:loc[0]]
+	}
+	return nil
+}
+
+// countVanityLetters returns the number of letters in a vanity candidate.
+func countVanityLetters(candidate string) int {
+	letters := 0
+	for _, c := range candidate {
+		if isVanityLetter(c) {
+			letters++
+		}
+	}
+	return letters
+}
+
+// isVanityLetter reports whether c is a letter of a vanity number, in either
+// case.
+func isVanityLetter(c rune) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
+
 // extractInnerMatch attempts to extract a match from candidate if the whole
 // candidate does not qualify as a match.
 func (m *phoneNumberMatcher) extractInnerMatch(candidate string, offset int) *PhoneNumberMatch {
 	for _, possibleInnerMatch := range innerMatches {
 		isFirstMatch := true
 		for _, g := range possibleInnerMatch.FindAllStringSubmatchIndex(candidate, -1) {
-			if m.maxTries <= 0 {
+			if !m.canTry() {
 				break
 			}
 			if isFirstMatch {
@@ -250,71 +440,190 @@ func (m *phoneNumberMatcher) extractInnerMatch(candidate string, offset int) *Ph
 				if match := m.parseAndVerify(group, offset); match != nil {
 					return match
 				}
-				m.maxTries--
+				m.failedTry()
 				isFirstMatch = false
 			}
 			group := trimAfterFirstMatch(unwantedEndCharPattern, candidate[g[2]:g[3]])
 			if match := m.parseAndVerify(group, offset+g[2]); match != nil {
 				return match
 			}
-			m.maxTries--
+			m.failedTry()
 		}
 	}
 	return nil
 }
 
-// parseAndVerify parses a phone number from candidate and verifies it matches
-// the requested leniency, returning a PhoneNumberMatch or nil.
-func (m *phoneNumberMatcher) parseAndVerify(candidate string, offset int) *PhoneNumberMatch {
+// checkCandidate reports whether candidate, at offset, could be a number
+// before it is parsed, i.e. it has no formatting which would indicate it
+// isn't one, and the verifier doesn't reject it for its surroundings.
+func (m *phoneNumberMatcher) checkCandidate(candidate string, offset int) bool {
+	if m.stats != nil {
+		m.stats.Candidates++
+	}
+
 	// Check the candidate doesn't contain any formatting which would indicate
 	// that it really isn't a phone number.
 	if !matchingBrackets.MatchString(candidate) || pubPages.MatchString(candidate) {
-		return nil
+		if m.stats != nil {
+			m.stats.Malformed++
+		}
+		return false
 	}
 
-	// If leniency is VALID or stricter, skip numbers surrounded by Latin
+	// If the verifier is VALID or stricter, skip numbers surrounded by Latin
 	// alphabetic characters, to skip cases like abc8005001234 or 8005001234def.
-	if m.leniency >= VALID {
+	if requiresSeparation(m.verifier) {
 		// If the candidate is not at the start of the text, and does not start
 		// with phone-number punctuation, check the previous character.
 		if offset > 0 && leadClassPattern.FindStringIndex(candidate) == nil {
 			previousChar, _ := utf8.DecodeLastRuneInString(m.text[:offset])
 			if isInvalidPunctuationSymbol(previousChar) || isLatinLetter(previousChar) {
-				return nil
+				m.reject(nil, candidate)
+				return false
 			}
 		}
 		lastCharIndex := offset + len(candidate)
 		if lastCharIndex < len(m.text) {
 			nextChar, _ := utf8.DecodeRuneInString(m.text[lastCharIndex:])
 			if isInvalidPunctuationSymbol(nextChar) || isLatinLetter(nextChar) {
-				return nil
+				m.reject(nil, candidate)
+				return false
 			}
 		}
 	}
 
-	number := &PhoneNumber{}
-	if err := ParseAndKeepRawInputToNumber(candidate, m.preferredRegion, number); err != nil {
-		// ignore and continue
+	return true
+}
+
+// parseAndVerify parses a phone number from candidate and verifies it with the
+// matcher's verifier, returning a PhoneNumberMatch or nil.
+func (m *phoneNumberMatcher) parseAndVerify(candidate string, offset int) *PhoneNumberMatch {
+	if !m.checkCandidate(candidate, offset) {
 		return nil
 	}
 
-	if m.leniency.Verify(number, candidate) {
-		// We used ParseAndKeepRawInput to create this number, but for now we
-		// don't return the extra values parsed.
-		number.CountryCodeSource = nil
-		number.RawInput = nil
-		number.PreferredDomesticCarrierCode = nil
-		return newPhoneNumberMatch(offset, candidate, number)
+	// the first region which gives a number the verifier accepts wins
+	var rejected *PhoneNumber
+	for _, region := range m.regions {
+		number := &PhoneNumber{}
+		if err := m.util.ParseAndKeepRawInputToNumber(candidate, region, number); err != nil {
+			// ignore and continue
+			continue
+		}
+
+		if m.verifier.VerifyNumber(m.util, number, candidate) {
+			// We used ParseAndKeepRawInput to create this number, but for now we
+			// don't return the extra values parsed.
+			countryCodeSource := number.GetCountryCodeSource()
+			region := m.matchRegion(number, region)
+			number.CountryCodeSource = nil
+			number.RawInput = nil
+			number.PreferredDomesticCarrierCode = nil
+			match := newPhoneNumberMatch(offset, candidate, number)
+			match.region = region
+			match.countryCodeSource = countryCodeSource
+			return match
+		}
+		if rejected == nil {
+			rejected = number
+		}
+	}
+
+	if rejected == nil {
+		if m.stats != nil {
+			m.stats.Malformed++
+		}
+		return nil
 	}
+	m.reject(rejected, candidate)
 	return nil
 }
 
+// parseAndVerifyVanity is parseAndVerify for a vanity candidate.
+// Letters beyond the length of a number aren't dialled, as with the "FT" of
+// "1-800-MICROSOFT", so trailing letters are dropped until what is left is a
+// number the verifier accepts, but the match is of the whole candidate. The
+// number keeps the candidate as its raw input, for
+// FormatOutOfCountryKeepingAlphaChars.
+func (m *phoneNumberMatcher) parseAndVerifyVanity(candidate string, offset int) *PhoneNumberMatch {
+	if !m.checkCandidate(candidate, offset) {
+		return nil
+	}
+
+	var rejected *PhoneNumber
+	var rejectedDialled string
+	for dialled := candidate; ; {
+		// verify the digits the letters stand for, as for a number written
+		// with digits
+		converted := ConvertAlphaCharactersInNumber(dialled)
+		for _, region := range m.regions {
+			number := &PhoneNumber{}
+			if err := m.util.ParseAndKeepRawInputToNumber(dialled, region, number); err != nil {
+				continue
+			}
+			if m.verifier.VerifyNumber(m.util, number, converted) {
+				countryCodeSource := number.GetCountryCodeSource()
+				region := m.matchRegion(number, region)
+				number.CountryCodeSource = nil
+				number.RawInput = &candidate
+				number.PreferredDomesticCarrierCode = nil
+				match := newPhoneNumberMatch(offset, candidate, number)
+				match.region = region
+				match.countryCodeSource = countryCodeSource
+				return match
+			}
+			if rejected == nil {
+				rejected, rejectedDialled = number, converted
+			}
+		}
+
+		// drop the last letter, unless it is all that is left of its block
+		last, size := utf8.DecodeLastRuneInString(dialled)
+		shorter := dialled[:len(dialled)-size]
+		previous, _ := utf8.DecodeLastRuneInString(shorter)
+		if !isVanityLetter(last) || !isVanityLetter(previous) || countVanityLetters(shorter) < 3 {
+			break
+		}
+		dialled = shorter
+	}
+
+	if rejected == nil {
+		if m.stats != nil {
+			m.stats.Malformed++
+		}
+		return nil
+	}
+	m.reject(rejected, rejectedDialled)
+	return nil
+}
+
+// reject records in the matcher's stats, if any, that the verifier rejected
+// number, parsed from candidate, or rejected candidate before parsing it if
+// number is nil.
+func (m *phoneNumberMatcher) reject(number *PhoneNumber, candidate string) {
+	if m.stats == nil {
+		return
+	}
+	m.stats.Rejected++
+	if l, ok := m.verifier.(Leniency); ok {
+		// candidates are only rejected before parsing by VALID and stricter
+		level := VALID
+		if number != nil {
+			level = l.loosestFailed(number, candidate, m.util)
+		}
+		if m.stats.RejectedByLeniency == nil {
+			m.stats.RejectedByLeniency = make(map[Leniency]int)
+		}
+		m.stats.RejectedByLeniency[level]++
+	}
+}
+
 // getNationalNumberGroups returns the national-number part of a number,
 // formatted without any national prefix, as the set of digit blocks that would
 // be formatted together following standard formatting rules.
-func getNationalNumberGroups(number *PhoneNumber) []string {
+func getNationalNumberGroups(util *Util, number *PhoneNumber) []string {
 	// This will be in the format +CC-DG1-DG2-DGX;ext=EXT.
-	rfc3966Format := Format(number, RFC3966)
+	rfc3966Format := util.Format(number, RFC3966)
 	// Remove the extension part before splitting into groups.
 	endIndex := strings.IndexByte(rfc3966Format, ';')
 	if endIndex < 0 {
@@ -338,11 +647,12 @@ func getNationalNumberGroupsWithPattern(number *PhoneNumber, formattingPattern *
 func checkNumberGroupingIsValid(
 	number *PhoneNumber,
 	candidate string,
-	checker func(number *PhoneNumber, normalizedCandidate string, expectedNumberGroups []string) bool) bool {
+	util *Util,
+	checker func(util *Util, number *PhoneNumber, normalizedCandidate string, expectedNumberGroups []string) bool) bool {
 
 	normalizedCandidate := normalizeDigits(candidate, true /* keep non-digits */)
-	formattedNumberGroups := getNationalNumberGroups(number)
-	if checker(number, normalizedCandidate, formattedNumberGroups) {
+	formattedNumberGroups := getNationalNumberGroups(util, number)
+	if checker(util, number, normalizedCandidate, formattedNumberGroups) {
 		return true
 	}
 	// If this didn't pass, see if any alternate formats match instead.
@@ -359,7 +669,7 @@ func checkNumberGroupingIsValid(
 				}
 			}
 			formattedNumberGroups = getNationalNumberGroupsWithPattern(number, alternateFormat)
-			if checker(number, normalizedCandidate, formattedNumberGroups) {
+			if checker(util, number, normalizedCandidate, formattedNumberGroups) {
 				return true
 			}
 		}
@@ -371,6 +681,7 @@ func checkNumberGroupingIsValid(
 // candidate are not broken up differently from how the number would be
 // formatted (the STRICT_GROUPING check).
 func allNumberGroupsRemainGrouped(
+	util *Util,
 	number *PhoneNumber,
 	normalizedCandidate string,
 	formattedNumberGroups []string) bool {
@@ -397,9 +708,9 @@ func allNumberGroupsRemainGrouped(
 			// for formatting based on the country code rather than the number
 			// itself, as we don't need to distinguish between different countries
 			// with the same country calling code and this is faster.
-			region := GetRegionCodeForCountryCode(int(number.GetCountryCode()))
+			region := util.GetRegionCodeForCountryCode(int(number.GetCountryCode()))
 			nextChar, _ := utf8.DecodeRuneInString(normalizedCandidate[fromIndex:])
-			if GetNddPrefixForRegion(region, true) != "" && unicode.IsDigit(nextChar) {
+			if util.GetNddPrefixForRegion(region, true) != "" && unicode.IsDigit(nextChar) {
 				// There is no formatting symbol after the NDC. In this case we
 				// only accept the number if there is no formatting symbol at all
 				// in the number, except for extensions. Only important for
@@ -419,6 +730,7 @@ func allNumberGroupsRemainGrouped(
 // candidate exactly match how the number would be formatted (the EXACT_GROUPING
 // check).
 func allNumberGroupsAreExactlyPresent(
+	util *Util,
 	number *PhoneNumber,
 	normalizedCandidate string,
 	formattedNumberGroups []string) bool {
@@ -496,7 +808,7 @@ func containsMoreThanOneSlashInNationalNumber(number *PhoneNumber, candidate str
 
 // containsOnlyValidXChars reports whether every 'x'/'X' in candidate represents
 // a carrier code or an extension sign (and not, say, a vanity-number letter).
-func containsOnlyValidXChars(number *PhoneNumber, candidate string) bool {
+func containsOnlyValidXChars(number *PhoneNumber, candidate string, util *Util) bool {
 	// The characters 'x' and 'X' can be (1) a carrier code, in which case they
 	// always precede the national significant number or (2) an extension sign,
 	// in which case they always precede the extension number. We assume a
@@ -511,7 +823,7 @@ func containsOnlyValidXChars(number *PhoneNumber, candidate string) bool {
 				// Carrier code case: the 'X's always precede the national
 				// significant number.
 				index++
-				if IsNumberMatchWithOneNumber(number, candidate[index:]) != NSN_MATCH {
+				if util.IsNumberMatchWithOneNumber(number, candidate[index:]) != NSN_MATCH {
 					return false
 				}
 				// Extension sign case: the 'x' or 'X' should always precede the
@@ -526,14 +838,14 @@ func containsOnlyValidXChars(number *PhoneNumber, candidate string) bool {
 
 // isNationalPrefixPresentIfRequired reports whether, if a national prefix is
 // required to format number, it was present in the raw input.
-func isNationalPrefixPresentIfRequired(number *PhoneNumber) bool {
+func isNationalPrefixPresentIfRequired(number *PhoneNumber, util *Util) bool {
 	// First, check how we deduced the country code. If it was written in
 	// international format, then the national prefix is not required.
 	if number.GetCountryCodeSource() != PhoneNumber_FROM_DEFAULT_COUNTRY {
 		return true
 	}
-	phoneNumberRegion := GetRegionCodeForCountryCode(int(number.GetCountryCode()))
-	metadata := getMetadataForRegion(phoneNumberRegion)
+	phoneNumberRegion := util.GetRegionCodeForCountryCode(int(number.GetCountryCode()))
+	metadata := util.getMetadataForRegion(phoneNumberRegion)
 	if metadata == nil {
 		return true
 	}
@@ -571,11 +883,23 @@ func (m *phoneNumberMatcher) hasNext() bool {
 		} else {
 			m.searchIndex = m.lastMatch.End()
 			m.state = matcherReady
+			m.annotate(m.lastMatch)
 		}
 	}
 	return m.state == matcherReady
 }
 
+// annotate sets the offsets and confidence of match, if the matcher was asked
+// for them.
+func (m *phoneNumberMatcher) annotate(match *PhoneNumberMatch) {
+	if m.cursor != nil {
+		m.cursor.setOffsets(match)
+	}
+	if m.confidence {
+		match.confidence = m.util.matchConfidence(m.text, match)
+	}
+}
+
 // next returns the next match, or nil if there is none (callers should guard
 // with hasNext).
 func (m *phoneNumberMatcher) next() *PhoneNumberMatch {
@@ -0,0 +1,129 @@
+package phonenumbers
+
+// Go-specific tests of the matcher with no counterpart in upstream's
+// PhoneNumberMatcherTest (ported in phonenumbermatcher_test.go), such as the
+// options given by MatchOptions.
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindVanityNumbers(t *testing.T) {
+	useTestMetadata(t)
+
+	type vanityMatch struct {
+		raw            string
+		nationalNumber uint64
+		formatted      string
+	}
+	find := func(text string, leniency Leniency) []vanityMatch {
+		var found []vanityMatch
+		for m := range FindNumbersWithLeniency(text, regionCode.US, leniency, math.MaxInt, MatchOptions{Vanity: true}) {
+			assert.Equal(t, text[m.Start():m.End()], m.RawString())
+			found = append(found, vanityMatch{m.RawString(), m.Number().GetNationalNumber(), FormatOutOfCountryKeepingAlphaChars(m.Number(), regionCode.GB)})
+		}
+		return found
+	}
+
+	assert.Equal(t, []vanityMatch{
+		{"1-800-FLOWERS", 8003569377, "00 1 800-FLOWERS"},
+		{"650 253 0000", 6502530000, "00 1 650 253 0000"},
+	}, find("Call 1-800-FLOWERS or 650 253 0000", VALID))
+
+	// letters beyond the length of the number aren't dialled
+	assert.Equal(t, []vanityMatch{{"+44 800 MICROSOFT", 8006427676, "(0800) MICROSOFT"}}, find("+44 800 MICROSOFT", VALID))
+	assert.Equal(t, []vanityMatch{{"1 800 GOT JUNK", 8004685865, "00 1 800 GOT JUNK"}}, find("Need 1 800 GOT JUNK?", STRICT_GROUPING))
+
+	// letters may be in either case
+	assert.Equal(t, []vanityMatch{{"1-800-flowers", 8003569377, "00 1 800-FLOWERS"}}, find("Call 1-800-flowers", VALID))
+	assert.Equal(t, []vanityMatch{{"+44 800 Microsoft", 8006427676, "(0800) MICROSOFT"}}, find("+44 800 Microsoft", VALID))
+
+	// but words following the number aren't part of it
+	assert.Equal(t, []vanityMatch{{"1-800-FLOWERS", 8003569377, "00 1 800-FLOWERS"}}, find("1-800-FLOWERS TODAY", VALID))
+	assert.Equal(t, []vanityMatch{{"650 253 0000", 6502530000, "00 1 650 253 0000"}}, find("650 253 0000 FOR SALE", VALID))
+
+	// numbers next to letters are still skipped by VALID
+	assert.Empty(t, find("1-800-FLOWERSé", VALID))
+	assert.Len(t, find("1-800-FLOWERSé", POSSIBLE), 1)
+
+	// vanity numbers are only found on request
+	assert.Empty(t, slices.Collect(FindNumbersWithLeniency("Call 1-800-FLOWERS", regionCode.US, VALID, math.MaxInt)))
+
+	// and may be found by the other ways of finding numbers
+	inHTML := slices.Collect(FindNumbersInHTML("Call <b>1-800</b>-FLOWERS", regionCode.US, VALID, math.MaxInt, MatchOptions{Vanity: true}))
+	require.Len(t, inHTML, 1)
+	assert.Equal(t, "1-800</b>-FLOWERS", inHTML[0].RawString())
+	assert.Equal(t, uint64(8003569377), inHTML[0].Number().GetNationalNumber())
+	linked := Linkify("Call 1-800-FLOWERS", regionCode.US, LinkifyOptions{Match: MatchOptions{Vanity: true}})
+	assert.Equal(t, `Call <a href="tel:+1-800-356-9377">1-800-FLOWERS</a>`, linked)
+
+	// a vanity number which isn't one and its digits are a single try and a
+	// single candidate
+	text := "Call 1-800-ABC, 1-800-DEF or 650 253 0000"
+	assert.Len(t, slices.Collect(FindNumbersWithLeniency(text, regionCode.US, VALID, 3, MatchOptions{Vanity: true})), 1)
+	assert.Empty(t, slices.Collect(FindNumbersWithLeniency(text, regionCode.US, VALID, 2, MatchOptions{Vanity: true})))
+
+	finder := NewFinder(regionCode.US, VALID, FinderOptions{Match: MatchOptions{Vanity: true}})
+	for result, err := range finder.Find(context.Background(), slices.Values([]string{text, "Call 1-800-FLOWERS"})) {
+		require.NoError(t, err)
+		stats := result.Stats
+		assert.Equal(t, stats.Candidates, len(result.Matches)+stats.Malformed+stats.Rejected)
+	}
+}
+
+func TestFindNumbersInRegions(t *testing.T) {
+	useTestMetadata(t)
+
+	text := "Call 650 253 0000 in the US, 020 7031 3000 in the UK or +64 3 331 6005."
+	find := func(defaultRegion string, regions []string, leniency Leniency) []string {
+		var found []string
+		for m := range FindNumbersWithLeniency(text, defaultRegion, leniency, math.MaxInt, MatchOptions{Regions: regions}) {
+			found = append(found, m.RawString()+" "+m.Region()+" => "+Format(m.Number(), E164))
+		}
+		return found
+	}
+
+	// each number goes to the first region it satisfies the leniency for,
+	// and numbers with a country calling code to their own region
+	assert.Equal(t, []string{
+		"650 253 0000 US => +16502530000",
+		"020 7031 3000 GB => +442070313000",
+		"+64 3 331 6005 NZ => +6433316005",
+	}, find(regionCode.US, []string{regionCode.GB}, VALID))
+	assert.Equal(t, []string{
+		"650 253 0000 US => +16502530000",
+		"020 7031 3000 GB => +442070313000",
+		"+64 3 331 6005 NZ => +6433316005",
+	}, find(regionCode.GB, []string{regionCode.US}, VALID))
+
+	// no regions is FindNumbersWithLeniency
+	assert.Equal(t, []string{
+		"650 253 0000 US => +16502530000",
+		"+64 3 331 6005 NZ => +6433316005",
+	}, find(regionCode.US, nil, VALID))
+	assert.Equal(t, []string{"+64 3 331 6005 NZ => +6433316005"}, find(unknownRegion, nil, VALID))
+
+	// numbers with an international prefix are in their own region too
+	matches := slices.Collect(FindNumbersWithLeniency("Call 011 44 20 7031 3000", regionCode.US, VALID, math.MaxInt))
+	require.Len(t, matches, 1)
+	assert.Equal(t, regionCode.GB, matches[0].Region())
+
+	// regions work with the other ways of finding numbers
+	matches = slices.Collect(FindNumbersWithLeniency("電話 020 7031 3000", regionCode.US, VALID, math.MaxInt, MatchOptions{Regions: []string{regionCode.GB}, Offsets: true}))
+	require.Len(t, matches, 1)
+	assert.Equal(t, regionCode.GB, matches[0].Region())
+	assert.Equal(t, 3, matches[0].RuneStart())
+
+	inHTML := slices.Collect(FindNumbersInHTML("<p>020 <b>7031</b> 3000</p>", regionCode.US, VALID, math.MaxInt, MatchOptions{Regions: []string{regionCode.GB}}))
+	require.Len(t, inHTML, 1)
+	assert.Equal(t, regionCode.GB, inHTML[0].Region())
+
+	verifier := AllOf(VALID, InRegion(regionCode.GB))
+	assert.Len(t, slices.Collect(FindNumbersWithVerifier(text, regionCode.US, verifier, math.MaxInt, MatchOptions{Regions: []string{regionCode.GB}})), 1)
+}
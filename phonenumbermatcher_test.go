@@ -32,12 +32,12 @@ func ccSource(s PhoneNumber_CountryCodeSource) *PhoneNumber_CountryCodeSource {
 
 // findMatcher mirrors upstream phoneUtil.findNumbers(text, region).iterator().
 func findMatcher(text, region string) *phoneNumberMatcher {
-	return newPhoneNumberMatcher(text, region, VALID, math.MaxInt)
+	return newPhoneNumberMatcher(DefaultUtil(), text, region, VALID, math.MaxInt)
 }
 
 // findMatcherLeniency mirrors phoneUtil.findNumbers(text, region, leniency, maxTries).iterator().
 func findMatcherLeniency(text, region string, leniency Leniency, maxTries int) *phoneNumberMatcher {
-	return newPhoneNumberMatcher(text, region, leniency, maxTries)
+	return newPhoneNumberMatcher(DefaultUtil(), text, region, leniency, maxTries)
 }
 
 func hasNoMatches(m *phoneNumberMatcher) bool { return !m.hasNext() }
@@ -0,0 +1,112 @@
+package phonenumbers
+
+import (
+	"io"
+	"iter"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// streamWindowSize is the size of the window FindNumbersInReader keeps
+	// onto its input.
+	streamWindowSize = 64 << 10
+
+	// streamLookahead is how much text FindNumbersInReader needs after a
+	// candidate before trusting that the candidate doesn't continue, and so
+	// how far from the end of the window it stops matching until more input
+	// has been read. Candidates are far shorter than this, unless padded
+	// with unusual amounts of whitespace before an extension.
+	streamLookahead = 1 << 10
+
+	// streamContext is how much text FindNumbersInReader keeps before where
+	// it resumes matching, for the matcher to check the character preceding
+	// a number, and for the confidence of matches near the start of the
+	// window.
+	streamContext = max(utf8.UTFMax, confidenceContextBefore)
+)
+
+// FindNumbersInReader is FindNumbersWithLeniency for text read from r, e.g. log
+// files or email dumps too large to hold in memory. Only a bounded window of
+// the text is kept, which slides along as r is read, with numbers split
+// across reads matched as if the text had been read at once. The start and
+// end of each match are byte offsets from the start of the reader, as are
+// their rune and UTF-16 offsets if MatchOptions.Offsets is set.
+//
+// If reading r fails, iteration ends by yielding the error.
+func (u *Util) FindNumbersInReader(r io.Reader, defaultRegion string, leniency Leniency, maxTries int, opts ...MatchOptions) iter.Seq2[*PhoneNumberMatch, error] {
+	return func(yield func(*PhoneNumberMatch, error) bool) {
+		buf := make([]byte, 0, streamWindowSize)
+		base := 0  // offset in the stream of the start of the window
+		index := 0 // offset in the window to match from
+		eof := false
+		forced := false
+		var cursor *textCursor // shared by the windows' matchers, if any
+
+		for {
+			for !eof && len(buf) < cap(buf) {
+				n, err := r.Read(buf[len(buf):cap(buf)])
+				buf = buf[:len(buf)+n]
+				if err == io.EOF {
+					eof = true
+				} else if err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+
+			m := newPhoneNumberMatcher(u, string(buf), defaultRegion, leniency, maxTries)
+			m.setOptions(opts)
+			if m.cursor != nil {
+				if cursor == nil {
+					cursor = m.cursor
+				}
+				cursor.text = m.text
+				m.cursor = cursor
+			}
+			if !eof && !forced {
+				m.limit = len(buf) - streamLookahead
+			}
+			for {
+				match := m.find(index)
+				if match == nil {
+					break
+				}
+				m.annotate(match)
+				index = match.End()
+				match.start += base
+				match.rawString = strings.Clone(match.rawString)
+				if !yield(match, nil) {
+					return
+				}
+			}
+			maxTries = m.maxTries
+			if eof || maxTries == 0 {
+				return
+			}
+
+			// carry on matching from the candidate that reached the limit, or
+			// failing that from the limit itself, keeping the context before it
+			resume := max(index, len(buf)-streamLookahead)
+			if m.limitReached {
+				resume = m.limitCandidate
+			}
+			keepFrom := max(resume-streamContext, 0)
+
+			// if that would keep the whole window, the candidate is too long
+			// to ever fit, so match it as it is
+			forced = keepFrom == 0
+			if forced {
+				continue
+			}
+
+			if cursor != nil {
+				cursor.advance(keepFrom)
+				cursor.bytes -= keepFrom
+			}
+			buf = buf[:copy(buf, buf[keepFrom:])]
+			base += keepFrom
+			index = resume - keepFrom
+		}
+	}
+}
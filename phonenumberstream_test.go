@@ -0,0 +1,63 @@
+package phonenumbers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindNumbersInReader(t *testing.T) {
+	useTestMetadata(t)
+
+	// numbers at many offsets, so that some straddle the edges of the window
+	var sb strings.Builder
+	for i := 0; sb.Len() < 3*streamWindowSize; i++ {
+		sb.WriteString(strings.Repeat("lorem ipsum ", i%7))
+		switch i % 3 {
+		case 0:
+			fmt.Fprintf(&sb, "call 650 253 %04d, ", i%10000)
+		case 1:
+			fmt.Fprintf(&sb, "or +64 3 331 %04d ext. %d ", i%10000, i%100)
+		case 2:
+			sb.WriteString("2011-02-03 08:00 3/10/2011 ")
+		}
+	}
+	text := sb.String()
+
+	var expected []string
+	for m := range FindNumbersWithLeniency(text, regionCode.US, VALID, math.MaxInt) {
+		expected = append(expected, m.String())
+	}
+	require.Greater(t, len(expected), 1000)
+
+	for _, r := range []io.Reader{strings.NewReader(text), iotest.HalfReader(strings.NewReader(text)), iotest.OneByteReader(strings.NewReader(text))} {
+		var actual []string
+		for m, err := range FindNumbersInReader(r, regionCode.US, VALID, math.MaxInt) {
+			require.NoError(t, err)
+			assert.Equal(t, text[m.Start():m.End()], m.RawString())
+			actual = append(actual, m.String())
+		}
+		assert.Equal(t, expected, actual)
+	}
+
+	// read errors end iteration
+	var lastErr error
+	count := 0
+	for m, err := range FindNumbersInReader(io.MultiReader(strings.NewReader(text[:100000]), iotest.ErrReader(errors.New("boom"))), regionCode.US, VALID, math.MaxInt) {
+		if err != nil {
+			lastErr = err
+		} else {
+			assert.NotNil(t, m)
+			count++
+		}
+	}
+	assert.EqualError(t, lastErr, "boom")
+	assert.Greater(t, count, 0)
+}
@@ -438,6 +438,30 @@ var (
 	}
 )
 
+// Util is the analogue of an upstream PhoneNumberUtil instance: the parsing,
+// formatting and validation API bound to one metadata container. Most callers
+// use the package-level functions, which delegate to DefaultUtil; construct a
+// Util with NewUtil to work against other metadata, such as a newer numbering
+// plan run side by side with the embedded one or synthetic test metadata. A
+// Util is safe for concurrent use.
+type Util struct {
+	container *metadata.Container
+}
+
+// NewUtil returns a Util that reads all metadata from c.
+func NewUtil(c *metadata.Container) *Util {
+	return &Util{container: c}
+}
+
+// DefaultUtil returns a Util bound to the active metadata container (see
+// metadata.Current). The package-level functions delegate to it.
+func DefaultUtil() *Util {
+	return &Util{container: metadata.Current()}
+}
+
+// Metadata returns the metadata container u reads from.
+func (u *Util) Metadata() *metadata.Container { return u.container }
+
 // Attempts to extract a possible number from the string passed in.
 // This currently strips all leading characters that cannot be used to
 // start a phone number. Characters that can be used to start a phone
@@ -449,22 +473,30 @@ var (
 // actually two phone numbers, (530) 583-6985 x302 and (530) 583-6985 x2303.
 // We remove the second extension so that the first number is parsed correctly.
 func extractPossibleNumber(number string) string {
+	start, end := possibleNumberRange(number)
+	return number[start:end]
+}
+
+// possibleNumberRange returns the start and end offsets in number of the
+// possible number extractPossibleNumber extracts, both len(number) if there
+// is none.
+func possibleNumberRange(number string) (int, int) {
 	if validStartCharPattern.MatchString(number) {
 		start := validStartCharPattern.FindIndex([]byte(number))[0]
-		number = number[start:]
+		possibleNumber := number[start:]
 		// Remove trailing non-alpha non-numerical characters.
-		indices := unwantedEndCharPattern.FindIndex([]byte(number))
+		indices := unwantedEndCharPattern.FindIndex([]byte(possibleNumber))
 		if len(indices) > 0 {
-			number = number[0:indices[0]]
+			possibleNumber = possibleNumber[0:indices[0]]
 		}
 		// Check for extra numbers at the end.
-		indices = secondNumberStartPattern.FindIndex([]byte(number))
+		indices = secondNumberStartPattern.FindIndex([]byte(possibleNumber))
 		if len(indices) > 0 {
-			number = number[0:indices[0]]
+			possibleNumber = possibleNumber[0:indices[0]]
 		}
-		return number
+		return start, start + len(possibleNumber)
 	}
-	return ""
+	return len(number), len(number)
 }
 
 // Checks to see if the string of characters could possibly be a phone
@@ -575,13 +607,13 @@ func ConvertAlphaCharactersInNumber(number string) string {
 //   - most non-geographical numbers have no area codes, including numbers from
 //     non-geographical entities
 //   - some geographical numbers have no area codes.
-func GetLengthOfGeographicalAreaCode(number *PhoneNumber) int {
-	metadata := getMetadataForRegion(GetRegionCodeForNumber(number))
+func (u *Util) GetLengthOfGeographicalAreaCode(number *PhoneNumber) int {
+	metadata := u.getMetadataForRegion(u.GetRegionCodeForNumber(number))
 	if metadata == nil {
 		return 0
 	}
 
-	numType := GetNumberType(number)
+	numType := u.GetNumberType(number)
 	countryCallingCode := number.GetCountryCode()
 
 	// If a country doesn't use a national prefix, and this number doesn't have an Italian leading
@@ -599,11 +631,11 @@ func GetLengthOfGeographicalAreaCode(number *PhoneNumber) int {
 		return 0
 	}
 
-	if !IsNumberGeographical(number) {
+	if !u.IsNumberGeographical(number) {
 		return 0
 	}
 
-	return GetLengthOfNationalDestinationCode(number)
+	return u.GetLengthOfNationalDestinationCode(number)
 }
 
 // Gets the length of the national destination code (NDC) from the
@@ -630,7 +662,7 @@ func GetLengthOfGeographicalAreaCode(number *PhoneNumber) int {
 //
 // Refer to the unittests to see the difference between this function and
 // GetLengthOfGeographicalAreaCode().
-func GetLengthOfNationalDestinationCode(number *PhoneNumber) int {
+func (u *Util) GetLengthOfNationalDestinationCode(number *PhoneNumber) int {
 	var copiedProto *PhoneNumber
 	if len(number.GetExtension()) > 0 {
 		// We don't want to alter the proto given to us, but we don't
@@ -643,7 +675,7 @@ func GetLengthOfNationalDestinationCode(number *PhoneNumber) int {
 		copiedProto = number
 	}
 
-	nationalSignificantNumber := Format(copiedProto, INTERNATIONAL)
+	nationalSignificantNumber := u.Format(copiedProto, INTERNATIONAL)
 	numberGroups := nonDigitsPattern.Split(nationalSignificantNumber, -1)
 
 	// The pattern will start with "+COUNTRY_CODE " so the first group
@@ -653,7 +685,7 @@ func GetLengthOfNationalDestinationCode(number *PhoneNumber) int {
 	if len(numberGroups) <= 3 {
 		return 0
 	}
-	if GetNumberType(number) == MOBILE {
+	if u.GetNumberType(number) == MOBILE {
 		// For example Argentinian mobile numbers, when formatted in
 		// the international format, are in the form of +54 9 NDC XXXX....
 		// As a result, we take the length of the third group (NDC) and
@@ -701,21 +733,21 @@ func normalizeHelper(number string,
 }
 
 // GetSupportedRegions returns all regions the library has metadata for.
-func GetSupportedRegions() map[string]bool {
-	return metadata.SupportedRegions()
+func (u *Util) GetSupportedRegions() map[string]bool {
+	return u.container.SupportedRegions()
 }
 
 // GetSupportedCallingCodes returns all country calling codes the library has metadata for, covering both non-geographical
 // entities (global network calling codes) and those used for geographical entities. This could be
 // used to populate a drop-down box of country calling codes for a phone-number widget, for
 // instance.
-func GetSupportedCallingCodes() map[int]bool {
-	return metadata.SupportedCallingCodes()
+func (u *Util) GetSupportedCallingCodes() map[int]bool {
+	return u.container.SupportedCallingCodes()
 }
 
 // GetSupportedGlobalNetworkCallingCodes returns all global network calling codes the library has metadata for.
-func GetSupportedGlobalNetworkCallingCodes() map[int]bool {
-	return metadata.CountryCodesForNonGeographicalRegion()
+func (u *Util) GetSupportedGlobalNetworkCallingCodes() map[int]bool {
+	return u.container.CountryCodesForNonGeographicalRegion()
 }
 
 // allPhoneNumberTypes lists every PhoneNumberType, used to iterate types like
@@ -758,19 +790,19 @@ func getSupportedTypesForMetadata(metadata *PhoneMetadata) map[PhoneNumberType]b
 // GetSupportedTypesForRegion returns the types for a given region which the
 // library has metadata for. Will not include FIXED_LINE_OR_MOBILE or UNKNOWN.
 // No types are returned for invalid or unknown region codes.
-func GetSupportedTypesForRegion(regionCode string) map[PhoneNumberType]bool {
-	if !isValidRegionCode(regionCode) {
+func (u *Util) GetSupportedTypesForRegion(regionCode string) map[PhoneNumberType]bool {
+	if !u.isValidRegionCode(regionCode) {
 		return map[PhoneNumberType]bool{}
 	}
-	return getSupportedTypesForMetadata(getMetadataForRegion(regionCode))
+	return getSupportedTypesForMetadata(u.getMetadataForRegion(regionCode))
 }
 
 // GetSupportedTypesForNonGeoEntity returns the types for a country-code
 // belonging to a non-geographical entity which the library has metadata for.
 // Will not include FIXED_LINE_OR_MOBILE or UNKNOWN. No types are returned for
 // country calling codes that do not map to a known non-geographical entity.
-func GetSupportedTypesForNonGeoEntity(countryCallingCode int) map[PhoneNumberType]bool {
-	metadata := getMetadataForNonGeographicalRegion(countryCallingCode)
+func (u *Util) GetSupportedTypesForNonGeoEntity(countryCallingCode int) map[PhoneNumberType]bool {
+	metadata := u.getMetadataForNonGeographicalRegion(countryCallingCode)
 	if metadata == nil {
 		return map[PhoneNumberType]bool{}
 	}
@@ -793,8 +825,8 @@ func formattingRuleHasFirstGroupOnly(nationalPrefixFormattingRule string) bool {
 // overlap for geocodable and non-geocodable numbers. Also, if new phone
 // number types were added, we should check if this other method should be
 // updated too.
-func IsNumberGeographical(phoneNumber *PhoneNumber) bool {
-	return IsNumberGeographicalForType(GetNumberType(phoneNumber), int(phoneNumber.GetCountryCode()))
+func (u *Util) IsNumberGeographical(phoneNumber *PhoneNumber) bool {
+	return IsNumberGeographicalForType(u.GetNumberType(phoneNumber), int(phoneNumber.GetCountryCode()))
 }
 
 // Overload of IsNumberGeographical(PhoneNumber), since calculating the phone
@@ -807,14 +839,14 @@ func IsNumberGeographicalForType(phoneNumberType PhoneNumberType, countryCalling
 }
 
 // Helper function to check region code is not unknown or null.
-func isValidRegionCode(regionCode string) bool {
-	valid := metadata.SupportedRegions()[regionCode]
+func (u *Util) isValidRegionCode(regionCode string) bool {
+	valid := u.container.SupportedRegions()[regionCode]
 	return len(regionCode) != 0 && valid
 }
 
 // Helper function to check the country calling code is valid.
-func hasValidCountryCallingCode(countryCallingCode int) bool {
-	_, containsKey := metadata.CountryCodeToRegion()[countryCallingCode]
+func (u *Util) hasValidCountryCallingCode(countryCallingCode int) bool {
+	_, containsKey := u.container.CountryCodeToRegion()[countryCallingCode]
 	return containsKey
 }
 
@@ -828,7 +860,7 @@ func hasValidCountryCallingCode(countryCallingCode int) bool {
 // otherwise invalid country calling code, we cannot work out which
 // formatting rules to apply so we return the national significant number
 // with no formatting applied.
-func Format(number *PhoneNumber, numberFormat PhoneNumberFormat) string {
+func (u *Util) Format(number *PhoneNumber, numberFormat PhoneNumberFormat) string {
 	if number.GetNationalNumber() == 0 && len(number.GetRawInput()) > 0 {
 		// Unparseable numbers that kept their raw input just use that.
 		// This is the only case where a number can be formatted as E164
@@ -841,8 +873,14 @@ func Format(number *PhoneNumber, numberFormat PhoneNumberFormat) string {
 			return rawInput
 		}
 	}
+	if numberFormat == E164 {
+		// E164 applies no formatting rules, so build it directly rather
+		// than through formatWithBuf's intermediate buffers.
+		var buf [64]byte
+		return string(appendE164(buf[:0], number))
+	}
 	var formattedNumber = stringbuilder.New(nil)
-	formatWithBuf(number, numberFormat, formattedNumber)
+	u.formatWithBuf(number, numberFormat, formattedNumber)
 	return formattedNumber.String()
 }
 
@@ -851,7 +889,7 @@ func Format(number *PhoneNumber, numberFormat PhoneNumberFormat) string {
 // public Format. It is unexported because the buffer type is internal and the
 // allocation reuse it would offer is negligible anyway: formatting must prepend
 // the country calling code, which rebuilds the buffer on every call.
-func formatWithBuf(number *PhoneNumber, numberFormat PhoneNumberFormat, formattedNumber *stringbuilder.Builder) {
+func (u *Util) formatWithBuf(number *PhoneNumber, numberFormat PhoneNumberFormat, formattedNumber *stringbuilder.Builder) {
 	// Clear the StringBuilder first.
 	formattedNumber.Reset()
 	countryCallingCode := int(number.GetCountryCode())
@@ -864,7 +902,7 @@ func formatWithBuf(number *PhoneNumber, numberFormat PhoneNumberFormat, formatte
 		formattedNumber.WriteString(nationalSignificantNumber)
 		prefixNumberWithCountryCallingCode(countryCallingCode, E164, formattedNumber)
 		return
-	} else if !hasValidCountryCallingCode(countryCallingCode) {
+	} else if !u.hasValidCountryCallingCode(countryCallingCode) {
 		formattedNumber.WriteString(nationalSignificantNumber)
 		return
 	}
@@ -872,12 +910,12 @@ func formatWithBuf(number *PhoneNumber, numberFormat PhoneNumberFormat, formatte
 	// information for regions which share a country calling code is
 	// contained by only one region for performance reasons. For
 	// example, for NANPA regions it will be contained in the metadata for US.
-	regionCode := GetRegionCodeForCountryCode(countryCallingCode)
+	regionCode := u.GetRegionCodeForCountryCode(countryCallingCode)
 
 	// Metadata cannot be null because the country calling code is
 	// valid (which means that the region code cannot be ZZ and must
 	// be one of our supported region codes).
-	metadata := getMetadataForRegionOrCallingCode(countryCallingCode, regionCode)
+	metadata := u.getMetadataForRegionOrCallingCode(countryCallingCode, regionCode)
 
 	formattedNumber.WriteString(formatNsn(nationalSignificantNumber, metadata, numberFormat))
 	maybeAppendFormattedExtension(number, metadata, numberFormat, formattedNumber)
@@ -890,22 +928,22 @@ func formatWithBuf(number *PhoneNumber, numberFormat PhoneNumberFormat, formatte
 // work out things like whether there should be a national prefix applied,
 // or how to format extensions, so we return the national significant
 // number with no formatting applied.
-func FormatByPattern(number *PhoneNumber,
+func (u *Util) FormatByPattern(number *PhoneNumber,
 	numberFormat PhoneNumberFormat,
 	userDefinedFormats []*NumberFormat) string {
 
 	countryCallingCode := int(number.GetCountryCode())
 	nationalSignificantNumber := GetNationalSignificantNumber(number)
-	if !hasValidCountryCallingCode(countryCallingCode) {
+	if !u.hasValidCountryCallingCode(countryCallingCode) {
 		return nationalSignificantNumber
 	}
 	// Note GetRegionCodeForCountryCode() is used because formatting
 	// information for regions which share a country calling code is
 	// contained by only one region for performance reasons. For example,
 	// for NANPA regions it will be contained in the metadata for US.
-	regionCode := GetRegionCodeForCountryCode(countryCallingCode)
+	regionCode := u.GetRegionCodeForCountryCode(countryCallingCode)
 	// Metadata cannot be null because the country calling code is valid
-	metadata := getMetadataForRegionOrCallingCode(countryCallingCode, regionCode)
+	metadata := u.getMetadataForRegionOrCallingCode(countryCallingCode, regionCode)
 
 	formattedNumber := stringbuilder.New(nil)
 
@@ -960,19 +998,19 @@ func FormatByPattern(number *PhoneNumber,
 // regardless of whether the phone number already has a preferred domestic
 // carrier code stored. If carrierCode contains an empty string, returns
 // the number in national format without any carrier code.
-func FormatNationalNumberWithCarrierCode(number *PhoneNumber, carrierCode string) string {
+func (u *Util) FormatNationalNumberWithCarrierCode(number *PhoneNumber, carrierCode string) string {
 	countryCallingCode := int(number.GetCountryCode())
 	nationalSignificantNumber := GetNationalSignificantNumber(number)
-	if !hasValidCountryCallingCode(countryCallingCode) {
+	if !u.hasValidCountryCallingCode(countryCallingCode) {
 		return nationalSignificantNumber
 	}
 	// Note GetRegionCodeForCountryCode() is used because formatting
 	// information for regions which share a country calling code is
 	// contained by only one region for performance reasons. For
 	// example, for NANPA regions it will be contained in the metadata for US.
-	regionCode := GetRegionCodeForCountryCode(countryCallingCode)
+	regionCode := u.GetRegionCodeForCountryCode(countryCallingCode)
 	// Metadata cannot be null because the country calling code is valid.
-	metadata := getMetadataForRegionOrCallingCode(countryCallingCode, regionCode)
+	metadata := u.getMetadataForRegionOrCallingCode(countryCallingCode, regionCode)
 
 	formattedNumber := stringbuilder.New(nil)
 	formattedNumber.WriteString(
@@ -989,11 +1027,11 @@ func FormatNationalNumberWithCarrierCode(number *PhoneNumber, carrierCode string
 	return formattedNumber.String()
 }
 
-func getMetadataForRegionOrCallingCode(countryCallingCode int, regionCode string) *PhoneMetadata {
+func (u *Util) getMetadataForRegionOrCallingCode(countryCallingCode int, regionCode string) *PhoneMetadata {
 	if REGION_CODE_FOR_NON_GEO_ENTITY == regionCode {
-		return getMetadataForNonGeographicalRegion(countryCallingCode)
+		return u.getMetadataForNonGeographicalRegion(countryCallingCode)
 	}
-	return getMetadataForRegion(regionCode)
+	return u.getMetadataForRegion(regionCode)
 }
 
 // Formats a phone number in national format for dialing using the carrier
@@ -1006,7 +1044,7 @@ func getMetadataForRegionOrCallingCode(countryCallingCode int, regionCode string
 // Use formatNationalNumberWithCarrierCode instead if the carrier code
 // passed in should take precedence over the number's
 // preferredDomesticCarrierCode when formatting.
-func FormatNationalNumberWithPreferredCarrierCode(
+func (u *Util) FormatNationalNumberWithPreferredCarrierCode(
 	number *PhoneNumber,
 	fallbackCarrierCode string) string {
 
@@ -1014,20 +1052,20 @@ func FormatNationalNumberWithPreferredCarrierCode(
 	if number.GetPreferredDomesticCarrierCode() == "" {
 		pref = fallbackCarrierCode
 	}
-	return FormatNationalNumberWithCarrierCode(number, pref)
+	return u.FormatNationalNumberWithCarrierCode(number, pref)
 }
 
 // Returns a number formatted in such a way that it can be dialed from a
 // mobile phone in a specific region. If the number cannot be reached from
 // the region (e.g. some countries block toll-free numbers from being
 // called outside of the country), the method returns an empty string.
-func FormatNumberForMobileDialing(
+func (u *Util) FormatNumberForMobileDialing(
 	number *PhoneNumber,
 	regionCallingFrom string,
 	withFormatting bool) string {
 
 	countryCallingCode := int(number.GetCountryCode())
-	if !hasValidCountryCallingCode(countryCallingCode) {
+	if !u.hasValidCountryCallingCode(countryCallingCode) {
 		return number.GetRawInput() // go impl defaults to ""
 	}
 
@@ -1037,8 +1075,8 @@ func FormatNumberForMobileDialing(
 	var numberNoExt = &PhoneNumber{}
 	proto.Merge(numberNoExt, number)
 	numberNoExt.Extension = nil // can we assume this is safe? (no nil-pointer?)
-	regionCode := GetRegionCodeForCountryCode(countryCallingCode)
-	numberType := GetNumberType(numberNoExt)
+	regionCode := u.GetRegionCodeForCountryCode(countryCallingCode)
+	numberType := u.GetNumberType(numberNoExt)
 	isValidNumber := numberType != UNKNOWN
 	if regionCallingFrom == regionCode {
 		isFixedLineOrMobile :=
@@ -1053,7 +1091,7 @@ func FormatNumberForMobileDialing(
 			// treat the empty string the same as if it isn't set at all.
 			if numberNoExt.GetPreferredDomesticCarrierCode() != "" {
 				formattedNumber =
-					FormatNationalNumberWithPreferredCarrierCode(numberNoExt, "")
+					u.FormatNationalNumberWithPreferredCarrierCode(numberNoExt, "")
 			} else {
 				// Brazilian fixed line and mobile numbers need to be dialed
 				// with a carrier code when called within Brazil. Without
@@ -1066,11 +1104,11 @@ func FormatNumberForMobileDialing(
 			// numbers that can be dialed internationally, since that
 			// always works, except for numbers which might potentially be
 			// short numbers, which are always dialled in national format.
-			regionMetadata := getMetadataForRegion(regionCallingFrom)
-			if CanBeInternationallyDialled(numberNoExt) && testNumberLength(GetNationalSignificantNumber(numberNoExt), regionMetadata, UNKNOWN) != TOO_SHORT {
-				formattedNumber = Format(numberNoExt, INTERNATIONAL)
+			regionMetadata := u.getMetadataForRegion(regionCallingFrom)
+			if u.CanBeInternationallyDialled(numberNoExt) && testNumberLength(GetNationalSignificantNumber(numberNoExt), regionMetadata, UNKNOWN) != TOO_SHORT {
+				formattedNumber = u.Format(numberNoExt, INTERNATIONAL)
 			} else {
-				formattedNumber = Format(numberNoExt, NATIONAL)
+				formattedNumber = u.Format(numberNoExt, NATIONAL)
 			}
 		} else {
 			// For non-geographical countries, and Mexican and Chilean fixed
@@ -1092,21 +1130,21 @@ func FormatNumberForMobileDialing(
 			if (regionCode == REGION_CODE_FOR_NON_GEO_ENTITY ||
 				((regionCode == "MX" || regionCode == "CL" || regionCode == "UZ") &&
 					isFixedLineOrMobile)) &&
-				CanBeInternationallyDialled(numberNoExt) {
-				formattedNumber = Format(numberNoExt, INTERNATIONAL)
+				u.CanBeInternationallyDialled(numberNoExt) {
+				formattedNumber = u.Format(numberNoExt, INTERNATIONAL)
 			} else {
-				formattedNumber = Format(numberNoExt, NATIONAL)
+				formattedNumber = u.Format(numberNoExt, NATIONAL)
 			}
 		}
-	} else if isValidNumber && CanBeInternationallyDialled(numberNoExt) {
+	} else if isValidNumber && u.CanBeInternationallyDialled(numberNoExt) {
 		// We assume that short numbers are not diallable from outside
 		// their region, so if a number is not a valid regular length
 		// phone number, we treat it as if it cannot be internationally
 		// dialled.
 		if withFormatting {
-			return Format(numberNoExt, INTERNATIONAL)
+			return u.Format(numberNoExt, INTERNATIONAL)
 		}
-		return Format(numberNoExt, E164)
+		return u.Format(numberNoExt, E164)
 	}
 	if withFormatting {
 		return formattedNumber
@@ -1129,25 +1167,25 @@ func FormatNumberForMobileDialing(
 // In those cases, no international prefix is used. For regions which have
 // multiple international prefixes, the number in its INTERNATIONAL format
 // will be returned instead.
-func FormatOutOfCountryCallingNumber(
+func (u *Util) FormatOutOfCountryCallingNumber(
 	number *PhoneNumber,
 	regionCallingFrom string) string {
 
-	if !isValidRegionCode(regionCallingFrom) {
-		return Format(number, INTERNATIONAL)
+	if !u.isValidRegionCode(regionCallingFrom) {
+		return u.Format(number, INTERNATIONAL)
 	}
 	countryCallingCode := int(number.GetCountryCode())
 	nationalSignificantNumber := GetNationalSignificantNumber(number)
-	if !hasValidCountryCallingCode(countryCallingCode) {
+	if !u.hasValidCountryCallingCode(countryCallingCode) {
 		return nationalSignificantNumber
 	}
 	if countryCallingCode == nanpaCountryCode {
-		if IsNANPACountry(regionCallingFrom) {
+		if u.IsNANPACountry(regionCallingFrom) {
 			// For NANPA regions, return the national format for these
 			// regions but prefix it with the country calling code.
-			return strconv.Itoa(countryCallingCode) + " " + Format(number, NATIONAL)
+			return strconv.Itoa(countryCallingCode) + " " + u.Format(number, NATIONAL)
 		}
-	} else if countryCallingCode == getCountryCodeForValidRegion(regionCallingFrom) {
+	} else if countryCallingCode == u.getCountryCodeForValidRegion(regionCallingFrom) {
 		// If regions share a country calling code, the country calling
 		// code need not be dialled. This also applies when dialling
 		// within a region, so this if clause covers both these cases.
@@ -1157,10 +1195,10 @@ func FormatOutOfCountryCallingNumber(
 		// case for now and for those cases return the version including
 		// country calling code.
 		// Details here: http://www.petitfute.com/voyage/225-info-pratiques-reunion
-		return Format(number, NATIONAL)
+		return u.Format(number, NATIONAL)
 	}
 	// Metadata cannot be null because we checked 'isValidRegionCode()' above.
-	metadataForRegionCallingFrom := getMetadataForRegion(regionCallingFrom)
+	metadataForRegionCallingFrom := u.getMetadataForRegion(regionCallingFrom)
 	internationalPrefix := metadataForRegionCallingFrom.GetInternationalPrefix()
 
 	// For regions that have multiple international prefixes, the
@@ -1174,10 +1212,10 @@ func FormatOutOfCountryCallingNumber(
 		internationalPrefixForFormatting = internationalPrefix
 	}
 
-	regionCode := GetRegionCodeForCountryCode(countryCallingCode)
+	regionCode := u.GetRegionCodeForCountryCode(countryCallingCode)
 	// Metadata cannot be null because the country calling code is valid.
 	metadataForRegion :=
-		getMetadataForRegionOrCallingCode(countryCallingCode, regionCode)
+		u.getMetadataForRegionOrCallingCode(countryCallingCode, regionCode)
 	formattedNationalNumber :=
 		formatNsn(
 			nationalSignificantNumber, metadataForRegion, INTERNATIONAL)
@@ -1204,34 +1242,34 @@ func FormatOutOfCountryCallingNumber(
 //
 // Note this method guarantees no digit will be inserted, removed or
 // modified as a result of formatting.
-func FormatInOriginalFormat(number *PhoneNumber, regionCallingFrom string) string {
+func (u *Util) FormatInOriginalFormat(number *PhoneNumber, regionCallingFrom string) string {
 	rawInput := number.GetRawInput()
-	if len(rawInput) > 0 && !hasFormattingPatternForNumber(number) {
+	if len(rawInput) > 0 && !u.hasFormattingPatternForNumber(number) {
 		// We check if we have the formatting pattern because without that, we might format the number
 		// as a group without national prefix.
 		return rawInput
 	}
 	if number.GetCountryCodeSource() == 0 {
-		return Format(number, NATIONAL)
+		return u.Format(number, NATIONAL)
 	}
 	var formattedNumber string
 	switch number.GetCountryCodeSource() {
 	case PhoneNumber_FROM_NUMBER_WITH_PLUS_SIGN:
-		formattedNumber = Format(number, INTERNATIONAL)
+		formattedNumber = u.Format(number, INTERNATIONAL)
 	case PhoneNumber_FROM_NUMBER_WITH_IDD:
-		formattedNumber = FormatOutOfCountryCallingNumber(number, regionCallingFrom)
+		formattedNumber = u.FormatOutOfCountryCallingNumber(number, regionCallingFrom)
 	case PhoneNumber_FROM_NUMBER_WITHOUT_PLUS_SIGN:
-		formattedNumber = Format(number, INTERNATIONAL)[1:]
+		formattedNumber = u.Format(number, INTERNATIONAL)[1:]
 	case PhoneNumber_FROM_DEFAULT_COUNTRY:
 		// Fall-through to default case.
 		fallthrough
 	default:
-		regionCode := GetRegionCodeForCountryCode(int(number.GetCountryCode()))
+		regionCode := u.GetRegionCodeForCountryCode(int(number.GetCountryCode()))
 		// We strip non-digits from the NDD here, and from the raw
 		// input later, so that we can compare them easily.
-		nationalPrefix := GetNddPrefixForRegion(
+		nationalPrefix := u.GetNddPrefixForRegion(
 			regionCode, true /* strip non-digits */)
-		nationalFormat := Format(number, NATIONAL)
+		nationalFormat := u.Format(number, NATIONAL)
 		if len(nationalPrefix) == 0 {
 			// If the region doesn't have a national prefix at all,
 			// we can safely return the national format without worrying
@@ -1241,14 +1279,14 @@ func FormatInOriginalFormat(number *PhoneNumber, regionCallingFrom string) strin
 		}
 		// Otherwise, we check if the original number was entered with
 		// a national prefix.
-		if rawInputContainsNationalPrefix(rawInput, nationalPrefix, regionCode) {
+		if u.rawInputContainsNationalPrefix(rawInput, nationalPrefix, regionCode) {
 			// If so, we can safely return the national format.
 			formattedNumber = nationalFormat
 			break
 		}
 		// Metadata cannot be null here because GetNddPrefixForRegion()
 		// (above) returns null if there is no metadata for the region.
-		metadata := getMetadataForRegion(regionCode)
+		metadata := u.getMetadataForRegion(regionCode)
 		nationalNumber := GetNationalSignificantNumber(number)
 		formatRule :=
 			chooseFormattingPatternForNumber(metadata.GetNumberFormat(), nationalNumber)
@@ -1286,7 +1324,7 @@ func FormatInOriginalFormat(number *PhoneNumber, regionCallingFrom string) strin
 		proto.Merge(numFormatCopy, formatRule)
 		numFormatCopy.NationalPrefixFormattingRule = nil
 		var numberFormats = []*NumberFormat{numFormatCopy}
-		formattedNumber = FormatByPattern(number, NATIONAL, numberFormats)
+		formattedNumber = u.FormatByPattern(number, NATIONAL, numberFormats)
 	}
 	rawInput = number.GetRawInput()
 	// If no digit is inserted/removed/modified as a result of our
@@ -1305,7 +1343,7 @@ func FormatInOriginalFormat(number *PhoneNumber, regionCallingFrom string) strin
 // Check if rawInput, which is assumed to be in the national format, has
 // a national prefix. The national prefix is assumed to be in digits-only
 // form.
-func rawInputContainsNationalPrefix(rawInput, nationalPrefix, regionCode string) bool {
+func (u *Util) rawInputContainsNationalPrefix(rawInput, nationalPrefix, regionCode string) bool {
 	normalizedNationalNumber := NormalizeDigitsOnly(rawInput)
 	if strings.HasPrefix(normalizedNationalNumber, nationalPrefix) {
 		// Some Japanese numbers (e.g. 00777123) might be mistaken to
@@ -1313,20 +1351,20 @@ func rawInputContainsNationalPrefix(rawInput, nationalPrefix, regionCode string)
 		// (e.g. 0777123) if we just do prefix matching. To tackle that,
 		// we check the validity of the number if the assumed national
 		// prefix is removed (777123 won't be valid in Japan).
-		num, err := Parse(normalizedNationalNumber[len(nationalPrefix):], regionCode)
+		num, err := u.Parse(normalizedNationalNumber[len(nationalPrefix):], regionCode)
 		if err != nil {
 			return false
 		}
-		return IsValidNumber(num)
+		return u.IsValidNumber(num)
 
 	}
 	return false
 }
 
-func hasFormattingPatternForNumber(number *PhoneNumber) bool {
+func (u *Util) hasFormattingPatternForNumber(number *PhoneNumber) bool {
 	countryCallingCode := int(number.GetCountryCode())
-	phoneNumberRegion := GetRegionCodeForCountryCode(countryCallingCode)
-	metadata := getMetadataForRegionOrCallingCode(
+	phoneNumberRegion := u.GetRegionCodeForCountryCode(countryCallingCode)
+	metadata := u.getMetadataForRegionOrCallingCode(
 		countryCallingCode, phoneNumberRegion)
 	if metadata == nil {
 		return false
@@ -1357,7 +1395,7 @@ func hasFormattingPatternForNumber(number *PhoneNumber) bool {
 //     in the raw input before these digits. Normally people group the
 //     first three digits together so this is not a huge problem - and will
 //     be fixed if it proves to be so.
-func FormatOutOfCountryKeepingAlphaChars(
+func (u *Util) FormatOutOfCountryKeepingAlphaChars(
 	number *PhoneNumber,
 	regionCallingFrom string) string {
 
@@ -1366,10 +1404,10 @@ func FormatOutOfCountryKeepingAlphaChars(
 	// because there aren't any. In this case, we return
 	// formatOutOfCountryCallingNumber.
 	if len(rawInput) == 0 {
-		return FormatOutOfCountryCallingNumber(number, regionCallingFrom)
+		return u.FormatOutOfCountryCallingNumber(number, regionCallingFrom)
 	}
 	countryCode := int(number.GetCountryCode())
-	if !hasValidCountryCallingCode(countryCode) {
+	if !u.hasValidCountryCallingCode(countryCode) {
 		return rawInput
 	}
 	// Strip any prefix such as country calling code, IDD, that was
@@ -1389,13 +1427,13 @@ func FormatOutOfCountryKeepingAlphaChars(
 			rawInput = rawInput[firstNationalNumberDigit:]
 		}
 	}
-	metadataForRegionCallingFrom := getMetadataForRegion(regionCallingFrom)
+	metadataForRegionCallingFrom := u.getMetadataForRegion(regionCallingFrom)
 	if countryCode == nanpaCountryCode {
-		if IsNANPACountry(regionCallingFrom) {
+		if u.IsNANPACountry(regionCallingFrom) {
 			return strconv.Itoa(countryCode) + " " + rawInput
 		}
 	} else if metadataForRegionCallingFrom != nil &&
-		countryCode == getCountryCodeForValidRegion(regionCallingFrom) {
+		countryCode == u.getCountryCodeForValidRegion(regionCallingFrom) {
 		formattingPattern :=
 			chooseFormattingPatternForNumber(
 				metadataForRegionCallingFrom.GetNumberFormat(),
@@ -1434,9 +1472,9 @@ func FormatOutOfCountryKeepingAlphaChars(
 		}
 	}
 	var formattedNumber = stringbuilder.New([]byte(rawInput))
-	regionCode := GetRegionCodeForCountryCode(countryCode)
+	regionCode := u.GetRegionCodeForCountryCode(countryCode)
 	// Metadata cannot be null because the country calling code is valid.
-	var metadataForRegion *PhoneMetadata = getMetadataForRegionOrCallingCode(countryCode, regionCode)
+	var metadataForRegion *PhoneMetadata = u.getMetadataForRegionOrCallingCode(countryCode, regionCode)
 	// Strip any extension from the raw input before appending the formatted extension.
 	maybeStripExtension(formattedNumber)
 	maybeAppendFormattedExtension(number, metadataForRegion,
@@ -1666,21 +1704,21 @@ func formatNsnUsingPatternWithCarrier(
 }
 
 // Gets a valid number for the specified region.
-func GetExampleNumber(regionCode string) *PhoneNumber {
-	return GetExampleNumberForTypeInRegion(regionCode, FIXED_LINE)
+func (u *Util) GetExampleNumber(regionCode string) *PhoneNumber {
+	return u.GetExampleNumberForTypeInRegion(regionCode, FIXED_LINE)
 }
 
 // GetInvalidExampleNumber returns an invalid number for the specified region.
 // This is useful for unit-testing purposes, where you want to test what happens
 // with an invalid number. Returns nil when an unsupported region or the region
 // 001 (Earth) is passed in.
-func GetInvalidExampleNumber(regionCode string) *PhoneNumber {
-	if !isValidRegionCode(regionCode) {
+func (u *Util) GetInvalidExampleNumber(regionCode string) *PhoneNumber {
+	if !u.isValidRegionCode(regionCode) {
 		return nil
 	}
 	// We start off with a valid fixed-line number since every country supports
 	// this, then try to make it invalid by shortening it.
-	desc := getNumberDescByType(getMetadataForRegion(regionCode), FIXED_LINE)
+	desc := getNumberDescByType(u.getMetadataForRegion(regionCode), FIXED_LINE)
 	exampleNumber := desc.GetExampleNumber()
 	if exampleNumber == "" {
 		// This shouldn't happen; we have a test for this.
@@ -1688,9 +1726,9 @@ func GetInvalidExampleNumber(regionCode string) *PhoneNumber {
 	}
 	for phoneNumberLength := len(exampleNumber) - 1; phoneNumberLength >= minLengthForNSN; phoneNumberLength-- {
 		numberToTry := exampleNumber[:phoneNumberLength]
-		possiblyValidNumber, err := Parse(numberToTry, regionCode)
+		possiblyValidNumber, err := u.Parse(numberToTry, regionCode)
 		// Shouldn't error: we already checked the length and the region code.
-		if err == nil && !IsValidNumber(possiblyValidNumber) {
+		if err == nil && !u.IsValidNumber(possiblyValidNumber) {
 			return possiblyValidNumber
 		}
 	}
@@ -1702,16 +1740,16 @@ func GetInvalidExampleNumber(regionCode string) *PhoneNumber {
 //
 // This is the upstream getExampleNumberForType(String, PhoneNumberType)
 // overload; GetExampleNumberForType is the region-less variant.
-func GetExampleNumberForTypeInRegion(regionCode string, typ PhoneNumberType) *PhoneNumber {
+func (u *Util) GetExampleNumberForTypeInRegion(regionCode string, typ PhoneNumberType) *PhoneNumber {
 	// Check the region code is valid.
-	if !isValidRegionCode(regionCode) {
+	if !u.isValidRegionCode(regionCode) {
 		return nil
 	}
 	// PhoneNumberDesc (pointer?)
-	var desc = getNumberDescByType(getMetadataForRegion(regionCode), typ)
+	var desc = getNumberDescByType(u.getMetadataForRegion(regionCode), typ)
 	exNum := desc.GetExampleNumber()
 	if len(exNum) > 0 {
-		num, err := Parse(exNum, regionCode)
+		num, err := u.Parse(exNum, regionCode)
 		if err != nil {
 			return nil
 		}
@@ -1722,18 +1760,18 @@ func GetExampleNumberForTypeInRegion(regionCode string, typ PhoneNumberType) *Ph
 
 // Gets a valid number for the specified number type (it may belong to any
 // country).
-func GetExampleNumberForType(typ PhoneNumberType) *PhoneNumber {
-	for regionCode := range GetSupportedRegions() {
-		exampleNumber := GetExampleNumberForTypeInRegion(regionCode, typ)
+func (u *Util) GetExampleNumberForType(typ PhoneNumberType) *PhoneNumber {
+	for regionCode := range u.GetSupportedRegions() {
+		exampleNumber := u.GetExampleNumberForTypeInRegion(regionCode, typ)
 		if exampleNumber != nil {
 			return exampleNumber
 		}
 	}
 	// If there wasn't an example number for a region, try the non-geographical entities.
-	for countryCallingCode := range GetSupportedGlobalNetworkCallingCodes() {
-		desc := getNumberDescByType(getMetadataForNonGeographicalRegion(countryCallingCode), typ)
+	for countryCallingCode := range u.GetSupportedGlobalNetworkCallingCodes() {
+		desc := getNumberDescByType(u.getMetadataForNonGeographicalRegion(countryCallingCode), typ)
 		if exNum := desc.GetExampleNumber(); len(exNum) > 0 {
-			num, err := Parse("+"+strconv.Itoa(countryCallingCode)+exNum, unknownRegion)
+			num, err := u.Parse("+"+strconv.Itoa(countryCallingCode)+exNum, unknownRegion)
 			if err == nil {
 				return num
 			}
@@ -1744,8 +1782,8 @@ func GetExampleNumberForType(typ PhoneNumberType) *PhoneNumber {
 }
 
 // Gets a valid number for the specified country calling code for a non-geographical entity.
-func GetExampleNumberForNonGeoEntity(countryCallingCode int) *PhoneNumber {
-	var metadata *PhoneMetadata = getMetadataForNonGeographicalRegion(countryCallingCode)
+func (u *Util) GetExampleNumberForNonGeoEntity(countryCallingCode int) *PhoneNumber {
+	var metadata *PhoneMetadata = u.getMetadataForNonGeographicalRegion(countryCallingCode)
 	if metadata == nil {
 		return nil
 	}
@@ -1758,7 +1796,7 @@ func GetExampleNumberForNonGeoEntity(countryCallingCode int) *PhoneNumber {
 
 	for _, desc := range descPriority {
 		if desc != nil && desc.GetExampleNumber() != "" {
-			num, err := Parse("+"+strconv.Itoa(countryCallingCode)+desc.GetExampleNumber(), "ZZ")
+			num, err := u.Parse("+"+strconv.Itoa(countryCallingCode)+desc.GetExampleNumber(), "ZZ")
 			if err != nil {
 				return nil
 			}
@@ -1825,9 +1863,9 @@ func getNumberDescByType(
 }
 
 // Gets the type of a phone number.
-func GetNumberType(number *PhoneNumber) PhoneNumberType {
-	var regionCode string = GetRegionCodeForNumber(number)
-	var metadata *PhoneMetadata = getMetadataForRegionOrCallingCode(
+func (u *Util) GetNumberType(number *PhoneNumber) PhoneNumberType {
+	var regionCode string = u.GetRegionCodeForNumber(number)
+	var metadata *PhoneMetadata = u.getMetadataForRegionOrCallingCode(
 		int(number.GetCountryCode()), regionCode)
 	if metadata == nil {
 		return UNKNOWN
@@ -1888,20 +1926,20 @@ func getNumberTypeHelper(nationalNumber string, metadata *PhoneMetadata) PhoneNu
 
 // Returns the metadata for the given region code or nil if the region
 // code is invalid or unknown.
-func getMetadataForRegion(regionCode string) *PhoneMetadata {
-	if !isValidRegionCode(regionCode) {
+func (u *Util) getMetadataForRegion(regionCode string) *PhoneMetadata {
+	if !u.isValidRegionCode(regionCode) {
 		return nil
 	}
-	val, _ := metadata.RegionMetadata(regionCode)
+	val, _ := u.container.RegionMetadata(regionCode)
 	return val
 }
 
-func getMetadataForNonGeographicalRegion(countryCallingCode int) *PhoneMetadata {
-	_, ok := metadata.CountryCodeToRegion()[countryCallingCode]
+func (u *Util) getMetadataForNonGeographicalRegion(countryCallingCode int) *PhoneMetadata {
+	_, ok := u.container.CountryCodeToRegion()[countryCallingCode]
 	if !ok {
 		return nil
 	}
-	val, _ := metadata.NonGeoMetadata(countryCallingCode)
+	val, _ := u.container.NonGeoMetadata(countryCallingCode)
 	return val
 }
 
@@ -1938,9 +1976,9 @@ func isNumberMatchingDesc(nationalNumber string, numberDesc *PhoneNumberDesc) bo
 // Tests whether a phone number matches a valid pattern. Note this doesn't
 // verify the number is actually in use, which is impossible to tell by
 // just looking at a number itself.
-func IsValidNumber(number *PhoneNumber) bool {
-	var regionCode string = GetRegionCodeForNumber(number)
-	return IsValidNumberForRegion(number, regionCode)
+func (u *Util) IsValidNumber(number *PhoneNumber) bool {
+	var regionCode string = u.GetRegionCodeForNumber(number)
+	return u.IsValidNumberForRegion(number, regionCode)
 }
 
 // Tests whether a phone number is valid for a certain region. Note this
@@ -1955,10 +1993,10 @@ func IsValidNumber(number *PhoneNumber) bool {
 // example, this method will mark numbers from British Crown dependencies
 // such as the Isle of Man as invalid for the region "GB" (United Kingdom),
 // since it has its own region code, "IM", which may be undesirable.
-func IsValidNumberForRegion(number *PhoneNumber, regionCode string) bool {
+func (u *Util) IsValidNumberForRegion(number *PhoneNumber, regionCode string) bool {
 	var countryCode int = int(number.GetCountryCode())
-	var metadata *PhoneMetadata = getMetadataForRegionOrCallingCode(countryCode, regionCode)
-	if metadata == nil || (REGION_CODE_FOR_NON_GEO_ENTITY != regionCode && countryCode != getCountryCodeForValidRegion(regionCode)) {
+	var metadata *PhoneMetadata = u.getMetadataForRegionOrCallingCode(countryCode, regionCode)
+	if metadata == nil || (REGION_CODE_FOR_NON_GEO_ENTITY != regionCode && countryCode != u.getCountryCodeForValidRegion(regionCode)) {
 		// Either the region code was invalid, or the country calling
 		// code for this number does not match that of the region code.
 		return false
@@ -1969,19 +2007,19 @@ func IsValidNumberForRegion(number *PhoneNumber, regionCode string) bool {
 
 // Returns the region where a phone number is from. This could be used for
 // geocoding at the region level.
-func GetRegionCodeForNumber(number *PhoneNumber) string {
+func (u *Util) GetRegionCodeForNumber(number *PhoneNumber) string {
 	var countryCode int = int(number.GetCountryCode())
-	var regions []string = metadata.CountryCodeToRegion()[countryCode]
+	var regions []string = u.container.CountryCodeToRegion()[countryCode]
 	if len(regions) == 0 {
 		return ""
 	}
 	if len(regions) == 1 {
 		return regions[0]
 	}
-	return getRegionCodeForNumberFromRegionList(number, regions)
+	return u.getRegionCodeForNumberFromRegionList(number, regions)
 }
 
-func getRegionCodeForNumberFromRegionList(
+func (u *Util) getRegionCodeForNumberFromRegionList(
 	number *PhoneNumber,
 	regionCodes []string) string {
 
@@ -1990,7 +2028,7 @@ func getRegionCodeForNumberFromRegionList(
 		// If leadingDigits is present, use this. Otherwise, do
 		// full validation. Metadata cannot be null because the
 		// region codes come from the country calling code map.
-		var metadata *PhoneMetadata = getMetadataForRegion(regionCode)
+		var metadata *PhoneMetadata = u.getMetadataForRegion(regionCode)
 		if len(metadata.GetLeadingDigits()) > 0 {
 			patP := "^(?:" + metadata.GetLeadingDigits() + ")" // Non capturing grouping to support OR'ed alternatives (e.g. 555|1[78]|2)
 			pat := regexcache.For(patP)
@@ -2012,8 +2050,8 @@ func getRegionCodeForNumberFromRegionList(
 // (such as in the case of non-geographical calling codes like 800) the
 // value "001" will be returned (corresponding to the value for World in
 // the UN M.49 schema).
-func GetRegionCodeForCountryCode(countryCallingCode int) string {
-	var regionCodes []string = metadata.CountryCodeToRegion()[countryCallingCode]
+func (u *Util) GetRegionCodeForCountryCode(countryCallingCode int) string {
+	var regionCodes []string = u.container.CountryCodeToRegion()[countryCallingCode]
 	if len(regionCodes) == 0 {
 		return unknownRegion
 	}
@@ -2024,25 +2062,25 @@ func GetRegionCodeForCountryCode(countryCallingCode int) string {
 // calling code. For non-geographical country calling codes, the region
 // code 001 is returned. Also, in the case of no region code being found,
 // an empty list is returned.
-func GetRegionCodesForCountryCode(countryCallingCode int) []string {
-	var regionCodes []string = metadata.CountryCodeToRegion()[countryCallingCode]
+func (u *Util) GetRegionCodesForCountryCode(countryCallingCode int) []string {
+	var regionCodes []string = u.container.CountryCodeToRegion()[countryCallingCode]
 	return regionCodes
 }
 
 // Returns the country calling code for a specific region. For example, this
 // would be 1 for the United States, and 64 for New Zealand.
-func GetCountryCodeForRegion(regionCode string) int {
-	if !isValidRegionCode(regionCode) {
+func (u *Util) GetCountryCodeForRegion(regionCode string) int {
+	if !u.isValidRegionCode(regionCode) {
 		return 0
 	}
-	return getCountryCodeForValidRegion(regionCode)
+	return u.getCountryCodeForValidRegion(regionCode)
 }
 
 // Returns the country calling code for a specific region. For example,
 // this would be 1 for the United States, and 64 for New Zealand. Assumes
 // the region is already valid.
-func getCountryCodeForValidRegion(regionCode string) int {
-	var metadata *PhoneMetadata = getMetadataForRegion(regionCode)
+func (u *Util) getCountryCodeForValidRegion(regionCode string) int {
+	var metadata *PhoneMetadata = u.getMetadataForRegion(regionCode)
 	return int(metadata.GetCountryCode())
 }
 
@@ -2056,8 +2094,8 @@ func getCountryCodeForValidRegion(regionCode string) int {
 // regions, the national dialling prefix is used only for certain types
 // of numbers. Use the library's formatting functions to prefix the
 // national prefix when required.
-func GetNddPrefixForRegion(regionCode string, stripNonDigits bool) string {
-	var metadata *PhoneMetadata = getMetadataForRegion(regionCode)
+func (u *Util) GetNddPrefixForRegion(regionCode string, stripNonDigits bool) string {
+	var metadata *PhoneMetadata = u.getMetadataForRegion(regionCode)
 	if metadata == nil {
 		return ""
 	}
@@ -2076,8 +2114,8 @@ func GetNddPrefixForRegion(regionCode string, stripNonDigits bool) string {
 
 // Checks if this is a region under the North American Numbering Plan
 // Administration (NANPA).
-func IsNANPACountry(regionCode string) bool {
-	return metadata.IsNANPARegion(regionCode)
+func (u *Util) IsNANPACountry(regionCode string) bool {
+	return u.container.IsNANPARegion(regionCode)
 }
 
 // Checks if the number is a valid vanity (alpha) number such as 800
@@ -2099,8 +2137,8 @@ func IsAlphaNumber(number string) bool {
 
 // Convenience wrapper around IsPossibleNumberWithReason(). Instead of
 // returning the reason for failure, this method returns a boolean value.
-func IsPossibleNumber(number *PhoneNumber) bool {
-	possible := IsPossibleNumberWithReason(number)
+func (u *Util) IsPossibleNumber(number *PhoneNumber) bool {
+	possible := u.IsPossibleNumberWithReason(number)
 	return possible == IS_POSSIBLE || possible == IS_POSSIBLE_LOCAL_ONLY
 }
 
@@ -2117,12 +2155,12 @@ func IsPossibleNumber(number *PhoneNumber) bool {
 // number +1 650 253 0000 belongs to the US. When written in this form, it can
 // be dialled from any region. When written as 650 253 0000, it can only be
 // dialled from within the US.
-func IsPossibleNumberFromRegion(number string, regionDialingFrom string) bool {
-	num, err := Parse(number, regionDialingFrom)
+func (u *Util) IsPossibleNumberFromRegion(number string, regionDialingFrom string) bool {
+	num, err := u.Parse(number, regionDialingFrom)
 	if err != nil {
 		return false
 	}
-	return IsPossibleNumber(num)
+	return u.IsPossibleNumber(num)
 }
 
 // descHasPossibleNumberData returns true if there is any possible-length data
@@ -2254,7 +2292,7 @@ func testNumberLength(number string, metadata *PhoneMetadata, numberType PhoneNu
 //     and length (obviously includes the length of area codes for fixed
 //     line numbers), it will return false for the subscriber-number-only
 //     version.
-func IsPossibleNumberWithReason(number *PhoneNumber) ValidationResult {
+func (u *Util) IsPossibleNumberWithReason(number *PhoneNumber) ValidationResult {
 	nationalNumber := GetNationalSignificantNumber(number)
 	countryCode := int(number.GetCountryCode())
 	// Note: For Russian Fed and NANPA numbers, we just use the rules
@@ -2263,12 +2301,12 @@ func IsPossibleNumberWithReason(number *PhoneNumber) ValidationResult {
 	// but not valid. This would need to be revisited if the possible
 	// number pattern ever differed between various regions within
 	// those plans.
-	if !hasValidCountryCallingCode(countryCode) {
+	if !u.hasValidCountryCallingCode(countryCode) {
 		return INVALID_COUNTRY_CODE
 	}
-	regionCode := GetRegionCodeForCountryCode(countryCode)
+	regionCode := u.GetRegionCodeForCountryCode(countryCode)
 	// Metadata cannot be null because the country calling code is valid.
-	var metadata *PhoneMetadata = getMetadataForRegionOrCallingCode(countryCode, regionCode)
+	var metadata *PhoneMetadata = u.getMetadataForRegionOrCallingCode(countryCode, regionCode)
 	var generalNumDesc *PhoneNumberDesc = metadata.GetGeneralDesc()
 	// Handling case of numbers with no metadata.
 	if len(generalNumDesc.GetNationalNumberPattern()) == 0 {
@@ -2287,25 +2325,25 @@ func IsPossibleNumberWithReason(number *PhoneNumber) ValidationResult {
 // IsPossibleNumberForTypeWithReason checks whether a phone number is a possible
 // number of a particular type. For most number types, this is the same result
 // as IsPossibleNumberWithReason. See that method for details.
-func IsPossibleNumberForTypeWithReason(number *PhoneNumber, numberType PhoneNumberType) ValidationResult {
+func (u *Util) IsPossibleNumberForTypeWithReason(number *PhoneNumber, numberType PhoneNumberType) ValidationResult {
 	nationalNumber := GetNationalSignificantNumber(number)
 	countryCode := int(number.GetCountryCode())
 	// Note: For regions that share a country calling code, like NANPA numbers, we
 	// just use the rules from the default region since getRegionCodeForNumber will
 	// not work if the number is possible but not valid.
-	if !hasValidCountryCallingCode(countryCode) {
+	if !u.hasValidCountryCallingCode(countryCode) {
 		return INVALID_COUNTRY_CODE
 	}
-	regionCode := GetRegionCodeForCountryCode(countryCode)
+	regionCode := u.GetRegionCodeForCountryCode(countryCode)
 	// Metadata cannot be nil because the country calling code is valid.
-	metadata := getMetadataForRegionOrCallingCode(countryCode, regionCode)
+	metadata := u.getMetadataForRegionOrCallingCode(countryCode, regionCode)
 	return testNumberLength(nationalNumber, metadata, numberType)
 }
 
 // IsPossibleNumberForType returns true if the number is a possible number of the
 // given type (a more lenient check than IsValidNumberForRegion).
-func IsPossibleNumberForType(number *PhoneNumber, numberType PhoneNumberType) bool {
-	result := IsPossibleNumberForTypeWithReason(number, numberType)
+func (u *Util) IsPossibleNumberForType(number *PhoneNumber, numberType PhoneNumberType) bool {
+	result := u.IsPossibleNumberForTypeWithReason(number, numberType)
 	return result == IS_POSSIBLE || result == IS_POSSIBLE_LOCAL_ONLY
 }
 
@@ -2313,8 +2351,8 @@ func IsPossibleNumberForType(number *PhoneNumber, numberType PhoneNumberType) bo
 // to be valid, and resets the PhoneNumber object passed in to that valid
 // version. If no valid number could be extracted, the PhoneNumber object
 // passed in will not be modified.
-func TruncateTooLongNumber(number *PhoneNumber) bool {
-	if IsValidNumber(number) {
+func (u *Util) TruncateTooLongNumber(number *PhoneNumber) bool {
+	if u.IsValidNumber(number) {
 		return true
 	}
 	numberCopy := &PhoneNumber{}
@@ -2322,13 +2360,13 @@ func TruncateTooLongNumber(number *PhoneNumber) bool {
 	nationalNumber := number.GetNationalNumber()
 	nationalNumber /= 10
 	numberCopy.NationalNumber = proto.Uint64(nationalNumber)
-	if IsPossibleNumberWithReason(numberCopy) == TOO_SHORT || nationalNumber == 0 {
+	if u.IsPossibleNumberWithReason(numberCopy) == TOO_SHORT || nationalNumber == 0 {
 		return false
 	}
-	for !IsValidNumber(numberCopy) {
+	for !u.IsValidNumber(numberCopy) {
 		nationalNumber /= 10
 		numberCopy.NationalNumber = proto.Uint64(nationalNumber)
-		if IsPossibleNumberWithReason(numberCopy) == TOO_SHORT ||
+		if u.IsPossibleNumberWithReason(numberCopy) == TOO_SHORT ||
 			nationalNumber == 0 {
 			return false
 		}
@@ -2346,7 +2384,7 @@ func TruncateTooLongNumber(number *PhoneNumber) bool {
 // sign or IDD has already been removed. Returns 0 if fullNumber doesn't
 // start with a valid country calling code, and leaves nationalNumber
 // unmodified.
-func extractCountryCode(fullNumber, nationalNumber *stringbuilder.Builder) int {
+func (u *Util) extractCountryCode(fullNumber, nationalNumber *stringbuilder.Builder) int {
 	fullNumBytes := fullNumber.Bytes()
 	if len(fullNumBytes) == 0 || fullNumBytes[0] == '0' {
 		// Country codes do not begin with a '0'.
@@ -2358,7 +2396,7 @@ func extractCountryCode(fullNumber, nationalNumber *stringbuilder.Builder) int {
 	)
 	for i := 1; i <= maxLengthCountryCode && i <= numberLength; i++ {
 		potentialCountryCode, _ = strconv.Atoi(string(fullNumBytes[0:i]))
-		if _, ok := metadata.CountryCodeToRegion()[potentialCountryCode]; ok {
+		if _, ok := u.container.CountryCodeToRegion()[potentialCountryCode]; ok {
 			nationalNumber.Write(fullNumBytes[i:])
 			return potentialCountryCode
 		}
@@ -2385,12 +2423,13 @@ func extractCountryCode(fullNumber, nationalNumber *stringbuilder.Builder) int {
 // It will throw a NumberParseException if the number starts with a '+' but
 // the country calling code supplied after this does not match that of any
 // known region.
-func maybeExtractCountryCode(
+func (u *Util) maybeExtractCountryCode(
 	number string,
 	defaultRegionMetadata *PhoneMetadata,
 	nationalNumber *stringbuilder.Builder,
 	keepRawInput bool,
-	phoneNumber *PhoneNumber) (int, error) {
+	phoneNumber *PhoneNumber,
+	progress *parseProgress) (int, error) {
 
 	if len(number) == 0 {
 		return 0, nil
@@ -2402,23 +2441,32 @@ func maybeExtractCountryCode(
 		possibleCountryIddPrefix = defaultRegionMetadata.GetInternationalPrefix()
 	}
 
-	countryCodeSource :=
-		maybeStripInternationalPrefixAndNormalize(fullNumber, possibleCountryIddPrefix)
+	countryCodeSource, prefix :=
+		stripInternationalPrefixAndNormalize(fullNumber, possibleCountryIddPrefix)
+	trace := progress.tracer()
+	if trace != nil {
+		trace.internationalPrefix(fullNumber.String(), countryCodeSource, prefix)
+	}
 	if keepRawInput {
 		phoneNumber.CountryCodeSource = &countryCodeSource
 	}
 	if countryCodeSource != PhoneNumber_FROM_DEFAULT_COUNTRY {
 		if len(fullNumber.String()) <= minLengthForNSN {
+			progress.strippedPrefix(number, countryCodeSource, prefix)
 			return 0, ErrTooShortAfterIDD
 		}
-		potentialCountryCode := extractCountryCode(fullNumber, nationalNumber)
+		potentialCountryCode := u.extractCountryCode(fullNumber, nationalNumber)
 		if potentialCountryCode != 0 {
 			phoneNumber.CountryCode = proto.Int32(int32(potentialCountryCode))
+			if trace != nil {
+				trace.countryCode(potentialCountryCode, countryCodeSource)
+			}
 			return potentialCountryCode, nil
 		}
 
 		// If this fails, they must be using a strange country calling code
 		// that we don't recognize, or that doesn't exist.
+		progress.strippedPrefix(number, countryCodeSource, prefix)
 		return 0, ErrInvalidCountryCode
 	} else if defaultRegionMetadata != nil {
 		// Check to see if the number starts with the country calling code
@@ -2456,12 +2504,18 @@ func maybeExtractCountryCode(
 					phoneNumber.CountryCodeSource = &val
 				}
 				phoneNumber.CountryCode = proto.Int32(int32(defaultCountryCode))
+				if trace != nil {
+					trace.countryCode(defaultCountryCode, PhoneNumber_FROM_NUMBER_WITHOUT_PLUS_SIGN)
+				}
 				return defaultCountryCode, nil
 			}
 		}
 	}
 	// No country calling code present.
 	phoneNumber.CountryCode = proto.Int32(0)
+	if trace != nil {
+		trace.countryCode(0, PhoneNumber_FROM_DEFAULT_COUNTRY)
+	}
 	return 0, nil
 }
 
@@ -2495,27 +2549,40 @@ func maybeStripInternationalPrefixAndNormalize(
 	number *stringbuilder.Builder,
 	possibleIddPrefix string) PhoneNumber_CountryCodeSource {
 
+	countryCodeSource, _ := stripInternationalPrefixAndNormalize(number, possibleIddPrefix)
+	return countryCodeSource
+}
+
+// stripInternationalPrefixAndNormalize is maybeStripInternationalPrefixAndNormalize,
+// also returning the prefix it stripped: the plus signs as they appear in
+// number, or the normalized international prefix digits.
+func stripInternationalPrefixAndNormalize(
+	number *stringbuilder.Builder,
+	possibleIddPrefix string) (PhoneNumber_CountryCodeSource, string) {
+
 	numBytes := number.Bytes()
 	if len(numBytes) == 0 {
-		return PhoneNumber_FROM_DEFAULT_COUNTRY
+		return PhoneNumber_FROM_DEFAULT_COUNTRY, ""
 	}
 	// Check to see if the number begins with one or more plus signs.
 	ind := plusCharsPattern.FindIndex(numBytes) // Return is an int pair [start,end]
 	if len(ind) > 0 && ind[0] == 0 {            // Strictly match from string start
+		prefix := string(numBytes[:ind[1]])
 		number.ResetWith(numBytes[ind[1]:])
 		// Can now normalize the rest of the number since we've consumed
 		// the "+" sign at the start.
 		number.ResetWithString(normalize(number.String()))
-		return PhoneNumber_FROM_NUMBER_WITH_PLUS_SIGN
+		return PhoneNumber_FROM_NUMBER_WITH_PLUS_SIGN, prefix
 	}
 
 	// Attempt to parse the first digits as an international prefix.
 	iddPattern := regexcache.For(possibleIddPrefix)
-	number.ResetWithString(normalize(string(numBytes)))
+	normalized := normalize(string(numBytes))
+	number.ResetWithString(normalized)
 	if parsePrefixAsIdd(iddPattern, number) {
-		return PhoneNumber_FROM_NUMBER_WITH_IDD
+		return PhoneNumber_FROM_NUMBER_WITH_IDD, normalized[:len(normalized)-number.Len()]
 	}
-	return PhoneNumber_FROM_DEFAULT_COUNTRY
+	return PhoneNumber_FROM_DEFAULT_COUNTRY, ""
 }
 
 // Strips any national prefix (such as 0, 1) present in the number provided.
@@ -2613,8 +2680,8 @@ func maybeStripExtension(number *stringbuilder.Builder) string {
 // that the number to parse starts with a + symbol so that we can attempt
 // to infer the region from the number. Returns false if it cannot use the
 // region provided and the region cannot be inferred.
-func checkRegionForParsing(numberToParse, defaultRegion string) bool {
-	if !isValidRegionCode(defaultRegion) {
+func (u *Util) checkRegionForParsing(numberToParse, defaultRegion string) bool {
+	if !u.isValidRegionCode(defaultRegion) {
 		// If the number is null or empty, we can't infer the region.
 		if len(numberToParse) == 0 ||
 			!plusCharsPattern.MatchString(numberToParse) {
@@ -2629,36 +2696,54 @@ func checkRegionForParsing(numberToParse, defaultRegion string) bool {
 // possible number. Note that validation of whether the number is actually
 // a valid number for a particular region is not performed. This can be
 // done separately with IsValidNumber().
-func Parse(numberToParse, defaultRegion string) (*PhoneNumber, error) {
-	var phoneNumber *PhoneNumber = &PhoneNumber{}
-	err := ParseToNumber(numberToParse, defaultRegion, phoneNumber)
-	return phoneNumber, err
+//
+// Numbers already in E164 format (a plus sign followed only by ASCII
+// digits) take a fast path that skips the regular expressions of the slow
+// path and makes a single allocation, for the returned PhoneNumber.
+func (u *Util) Parse(numberToParse, defaultRegion string) (*PhoneNumber, error) {
+	p := &parsedNumber{}
+	err := u.parse(p, numberToParse, defaultRegion)
+	return &p.number, err
 }
 
 // Same as Parse(string, string), but accepts mutable PhoneNumber as a
 // parameter to decrease object creation when invoked many times.
-func ParseToNumber(numberToParse, defaultRegion string, phoneNumber *PhoneNumber) error {
-	return parseHelper(numberToParse, defaultRegion, false, true, phoneNumber)
+//
+// Numbers already in E164 format take a fast path that skips the regular
+// expressions of the slow path. It still makes two allocations, for the
+// country calling code and national number fields of phoneNumber, which are
+// replaced rather than written through so that numbers sharing them with
+// phoneNumber are unaffected.
+func (u *Util) ParseToNumber(numberToParse, defaultRegion string, phoneNumber *PhoneNumber) error {
+	if countryCode, nationalNumber, ok := u.parseE164(numberToParse); ok {
+		setE164Fields(phoneNumber, countryCode, nationalNumber)
+		return nil
+	}
+	var progress parseProgress
+	err := u.parseHelper(numberToParse, defaultRegion, false, true, phoneNumber, &progress)
+	return u.parseError(err, numberToParse, defaultRegion, progress.offset)
 }
 
 // Parses a string and returns it in proto buffer format. This method
 // differs from Parse() in that it always populates the raw_input field of
 // the protocol buffer with numberToParse as well as the country_code_source
 // field.
-func ParseAndKeepRawInput(
+func (u *Util) ParseAndKeepRawInput(
 	numberToParse, defaultRegion string) (*PhoneNumber, error) {
 	var phoneNumber *PhoneNumber = &PhoneNumber{}
-	return phoneNumber, ParseAndKeepRawInputToNumber(
+	return phoneNumber, u.ParseAndKeepRawInputToNumber(
 		numberToParse, defaultRegion, phoneNumber)
 }
 
 // Same as ParseAndKeepRawInput(String, String), but accepts a mutable
 // PhoneNumber as a parameter to decrease object creation when invoked many
 // times.
-func ParseAndKeepRawInputToNumber(
+func (u *Util) ParseAndKeepRawInputToNumber(
 	numberToParse, defaultRegion string,
 	phoneNumber *PhoneNumber) error {
-	return parseHelper(numberToParse, defaultRegion, true, true, phoneNumber)
+	var progress parseProgress
+	err := u.parseHelper(numberToParse, defaultRegion, true, true, phoneNumber, &progress)
+	return u.parseError(err, numberToParse, defaultRegion, progress.offset)
 }
 
 // FindNumbers returns an iterator over all phone-number matches in text. It is a
@@ -2672,17 +2757,35 @@ func ParseAndKeepRawInputToNumber(
 //	for m := range phonenumbers.FindNumbers(text, "US") {
 //		fmt.Println(m.RawString(), m.Start(), m.End())
 //	}
-func FindNumbers(text, defaultRegion string) iter.Seq[*PhoneNumberMatch] {
-	return FindNumbersWithLeniency(text, defaultRegion, VALID, math.MaxInt)
+func (u *Util) FindNumbers(text, defaultRegion string) iter.Seq[*PhoneNumberMatch] {
+	return u.FindNumbersWithLeniency(text, defaultRegion, VALID, math.MaxInt)
 }
 
 // FindNumbersWithLeniency returns an iterator over all phone-number matches in
 // text at the given leniency. maxTries caps the number of invalid candidates
 // tried before giving up, to bound degenerate inputs with many false positives
-// (use math.MaxInt for no practical limit). Must be >= 0.
-func FindNumbersWithLeniency(text, defaultRegion string, leniency Leniency, maxTries int) iter.Seq[*PhoneNumberMatch] {
+// (use math.MaxInt for no practical limit). Must be >= 0. opts may turn on
+// more kinds of matches, e.g. vanity numbers:
+//
+//	for m := range phonenumbers.FindNumbersWithLeniency(text, "US", phonenumbers.VALID, 100, phonenumbers.MatchOptions{Vanity: true}) {
+//		fmt.Println(phonenumbers.FormatOutOfCountryKeepingAlphaChars(m.Number(), "GB"))
+//	}
+func (u *Util) FindNumbersWithLeniency(text, defaultRegion string, leniency Leniency, maxTries int, opts ...MatchOptions) iter.Seq[*PhoneNumberMatch] {
+	return u.FindNumbersWithVerifier(text, defaultRegion, leniency, maxTries, opts...)
+}
+
+// FindNumbersWithVerifier is FindNumbersWithLeniency, but with the matches
+// decided by verifier, e.g. a combination of a Leniency and other checks made
+// with AllOf, so that candidates it rejects count towards maxTries:
+//
+//	verifier := phonenumbers.AllOf(phonenumbers.VALID, phonenumbers.OfType(phonenumbers.MOBILE))
+//	for m := range phonenumbers.FindNumbersWithVerifier(text, "GB", verifier, 100) {
+//		...
+//	}
+func (u *Util) FindNumbersWithVerifier(text, defaultRegion string, verifier Verifier, maxTries int, opts ...MatchOptions) iter.Seq[*PhoneNumberMatch] {
 	return func(yield func(*PhoneNumberMatch) bool) {
-		m := newPhoneNumberMatcher(text, defaultRegion, leniency, maxTries)
+		m := newPhoneNumberMatcher(u, text, defaultRegion, verifier, maxTries)
+		m.setOptions(opts)
 		for m.hasNext() {
 			if !yield(m.next()) {
 				return
@@ -2722,19 +2825,27 @@ var (
 // as the public Parse() method, with the exception that it allows the
 // default region to be null, for use by IsNumberMatch(). checkRegion should
 // be set to false if it is permitted for the default region to be null or
-// unknown ("ZZ").
-func parseHelper(
+// unknown ("ZZ"). If progress is non-nil, the offset at which parsing
+// failed is recorded in it, along with a trace of the steps taken if it has
+// one.
+func (u *Util) parseHelper(
 	numberToParse, defaultRegion string,
 	keepRawInput, checkRegion bool,
-	phoneNumber *PhoneNumber) error {
+	phoneNumber *PhoneNumber,
+	progress *parseProgress) error {
+	if progress == nil {
+		progress = &parseProgress{}
+	}
+	trace := progress.trace
 	if len(numberToParse) == 0 {
 		return ErrNotANumber
 	} else if len(numberToParse) > maxInputStringLength {
+		progress.offset = maxInputStringLength
 		return ErrNumTooLong
 	}
 
 	nationalNumber := stringbuilder.New(nil)
-	err := buildNationalNumberForParsing(numberToParse, nationalNumber)
+	err := buildNationalNumberForParsing(numberToParse, nationalNumber, progress)
 	if err != nil {
 		return err
 	}
@@ -2746,7 +2857,7 @@ func parseHelper(
 	// Check the region supplied is valid, or that the extracted number
 	// starts with some sort of + sign so the number's region can be determined.
 	if checkRegion &&
-		!checkRegionForParsing(nationalNumber.String(), defaultRegion) {
+		!u.checkRegionForParsing(nationalNumber.String(), defaultRegion) {
 		return ErrInvalidCountryCode
 	}
 
@@ -2759,40 +2870,50 @@ func parseHelper(
 	extension := maybeStripExtension(nationalNumber)
 	if len(extension) > 0 {
 		phoneNumber.Extension = proto.String(extension)
+		if trace != nil {
+			trace.add("maybeStripExtension", nationalNumber.String(), "extension "+strconv.Quote(extension))
+		}
 	}
-	var regionMetadata *PhoneMetadata = getMetadataForRegion(defaultRegion)
+	var regionMetadata *PhoneMetadata = u.getMetadataForRegion(defaultRegion)
 	// Check to see if the number is given in international format so we
 	// know whether this number is from the default region or not.
 	normalizedNationalNumber := stringbuilder.New(nil)
 	// TODO: This method should really just take in the string buffer that
 	// has already been created, and just remove the prefix, rather than
 	// taking in a string and then outputting a string buffer.
-	countryCode, err := maybeExtractCountryCode(
+	numberOffset := progress.offset
+	countryCode, err := u.maybeExtractCountryCode(
 		nationalNumber.String(), regionMetadata,
-		normalizedNationalNumber, keepRawInput, phoneNumber)
+		normalizedNationalNumber, keepRawInput, phoneNumber, progress)
 	if err != nil {
 		// There might be a plus at the beginning
 		inds := plusCharsPattern.FindStringIndex(nationalNumber.String())
 		if err == ErrInvalidCountryCode && len(inds) > 0 {
 			// Strip the plus-char, and try again.
-			countryCode, err = maybeExtractCountryCode(
+			if trace != nil {
+				trace.add("maybeExtractCountryCode", "", "no country calling code after plus sign, retrying without it")
+			}
+			progress.skip(inds[1])
+			countryCode, err = u.maybeExtractCountryCode(
 				nationalNumber.String()[inds[1]:], regionMetadata,
-				normalizedNationalNumber, keepRawInput, phoneNumber)
+				normalizedNationalNumber, keepRawInput, phoneNumber, progress)
 			if err != nil {
 				return err
 			} else if countryCode == 0 {
+				// reported just after the plus, as for the first attempt
 				return ErrInvalidCountryCode
 			}
+			progress.offset = numberOffset
 		} else {
 			return err
 		}
 	}
 	if countryCode != 0 {
-		phoneNumberRegion := GetRegionCodeForCountryCode(countryCode)
+		phoneNumberRegion := u.GetRegionCodeForCountryCode(countryCode)
 		if phoneNumberRegion != defaultRegion {
 			// Metadata cannot be null because the country calling
 			// code is valid.
-			regionMetadata = getMetadataForRegionOrCallingCode(
+			regionMetadata = u.getMetadataForRegionOrCallingCode(
 				countryCode, phoneNumberRegion)
 		}
 	} else {
@@ -2823,6 +2944,9 @@ func parseHelper(
 		// Otherwise, we don't do the stripping, since the original number
 		// could be a valid short number.
 		validationResult := testNumberLength(potentialNationalNumber.String(), regionMetadata, UNKNOWN)
+		if trace != nil {
+			trace.nationalPrefix(normalizedNationalNumber.String(), potentialNationalNumber.String(), carrierCode.String(), validationResult)
+		}
 		if validationResult != TOO_SHORT && validationResult != IS_POSSIBLE_LOCAL_ONLY && validationResult != INVALID_LENGTH {
 			normalizedNationalNumber = potentialNationalNumber
 			if keepRawInput && carrierCode.Len() > 0 {
@@ -2889,12 +3013,17 @@ func isPhoneContextValid(phoneContext string) bool {
 // number out of it and write to nationalNumber.
 func buildNationalNumberForParsing(
 	numberToParse string,
-	nationalNumber *stringbuilder.Builder) error {
+	nationalNumber *stringbuilder.Builder,
+	progress *parseProgress) error {
 
 	indexOfPhoneContext := strings.Index(numberToParse, rfc3966PhoneContext)
 
 	phoneContext := extractPhoneContext(numberToParse, indexOfPhoneContext)
 	if indexOfPhoneContext >= 0 && !isPhoneContextValid(phoneContext) {
+		progress.offset = indexOfPhoneContext
+		if progress.trace != nil {
+			progress.trace.phoneContext("", phoneContext, false)
+		}
 		return ErrNotANumber
 	}
 	if indexOfPhoneContext > 0 {
@@ -2921,11 +3050,15 @@ func buildNationalNumberForParsing(
 			indexOfNationalNumber = indexOfRfc3966Prefix + len(rfc3966Prefix)
 		}
 		nationalNumber.WriteString(numberToParse[indexOfNationalNumber:indexOfPhoneContext])
+		// Any prefix is stripped from the phone-context, not the input
+		progress.offset, progress.number = indexOfNationalNumber, -1
 	} else {
 		// Extract a possible number from the string passed in (this
 		// strips leading characters that could not be the start of a
 		// phone number.)
-		nationalNumber.WriteString(extractPossibleNumber(numberToParse))
+		start, end := possibleNumberRange(numberToParse)
+		nationalNumber.WriteString(numberToParse[start:end])
+		progress.offset, progress.number = start, start
 	}
 
 	// Delete the isdn-subaddress and everything after it if it is present.
@@ -2941,6 +3074,14 @@ func buildNationalNumberForParsing(
 	// This is because we are concerned about deleting content from a
 	// potential number string when there is no strong evidence that the
 	// number is actually written in RFC3966.
+	if progress.trace == nil {
+		return nil
+	}
+	if indexOfPhoneContext > 0 {
+		progress.trace.phoneContext(nationalNumber.String(), phoneContext, true)
+	} else {
+		progress.trace.possibleNumber(numberToParse, nationalNumber.String())
+	}
 	return nil
 }
 
@@ -3040,27 +3181,27 @@ func isNationalNumberSuffixOfTheOther(firstNumber, secondNumber *PhoneNumber) bo
 // Takes two phone numbers as strings and compares them for equality. This is
 // a convenience wrapper for IsNumberMatch(PhoneNumber, PhoneNumber). No
 // default region is known.
-func IsNumberMatch(firstNumber, secondNumber string) MatchType {
-	firstNumberAsProto, err := Parse(firstNumber, unknownRegion)
+func (u *Util) IsNumberMatch(firstNumber, secondNumber string) MatchType {
+	firstNumberAsProto, err := u.Parse(firstNumber, unknownRegion)
 	if err == nil {
-		return IsNumberMatchWithOneNumber(firstNumberAsProto, secondNumber)
-	} else if err != ErrInvalidCountryCode {
+		return u.IsNumberMatchWithOneNumber(firstNumberAsProto, secondNumber)
+	} else if !errors.Is(err, ErrInvalidCountryCode) {
 		return NOT_A_NUMBER
 	}
 
-	secondNumberAsProto, err := Parse(secondNumber, unknownRegion)
+	secondNumberAsProto, err := u.Parse(secondNumber, unknownRegion)
 	if err == nil {
-		return IsNumberMatchWithOneNumber(secondNumberAsProto, firstNumber)
-	} else if err != ErrInvalidCountryCode {
+		return u.IsNumberMatchWithOneNumber(secondNumberAsProto, firstNumber)
+	} else if !errors.Is(err, ErrInvalidCountryCode) {
 		return NOT_A_NUMBER
 	}
 
 	var firstNumberProto, secondNumberProto PhoneNumber
-	err = parseHelper(firstNumber, "", false, false, &firstNumberProto)
+	err = u.parseHelper(firstNumber, "", false, false, &firstNumberProto, nil)
 	if err != nil {
 		return NOT_A_NUMBER
 	}
-	err = parseHelper(secondNumber, "", false, false, &secondNumberProto)
+	err = u.parseHelper(secondNumber, "", false, false, &secondNumberProto, nil)
 	if err != nil {
 		return NOT_A_NUMBER
 	}
@@ -3070,26 +3211,26 @@ func IsNumberMatch(firstNumber, secondNumber string) MatchType {
 // Takes two phone numbers and compares them for equality. This is a
 // convenience wrapper for IsNumberMatch(PhoneNumber, PhoneNumber). No
 // default region is known.
-func IsNumberMatchWithOneNumber(
+func (u *Util) IsNumberMatchWithOneNumber(
 	firstNumber *PhoneNumber, secondNumber string) MatchType {
 	// First see if the second number has an implicit country calling
 	// code, by attempting to parse it.
-	secondNumberAsProto, err := Parse(secondNumber, unknownRegion)
+	secondNumberAsProto, err := u.Parse(secondNumber, unknownRegion)
 	if err == nil {
 		return IsNumberMatchWithNumbers(firstNumber, secondNumberAsProto)
 	}
-	if err != ErrInvalidCountryCode {
+	if !errors.Is(err, ErrInvalidCountryCode) {
 		return NOT_A_NUMBER
 	}
 	// The second number has no country calling code. EXACT_MATCH is no
 	// longer possible. We parse it as if the region was the same as that
 	// for the first number, and if EXACT_MATCH is returned, we replace
 	// this with NSN_MATCH.
-	firstNumberRegion := GetRegionCodeForCountryCode(int(firstNumber.GetCountryCode()))
+	firstNumberRegion := u.GetRegionCodeForCountryCode(int(firstNumber.GetCountryCode()))
 
 	if firstNumberRegion != unknownRegion {
 		secondNumberWithFirstNumberRegion, err :=
-			Parse(secondNumber, firstNumberRegion)
+			u.Parse(secondNumber, firstNumberRegion)
 		if err != nil {
 			return NOT_A_NUMBER
 		}
@@ -3103,7 +3244,7 @@ func IsNumberMatchWithOneNumber(
 		// If the first number didn't have a valid country calling
 		// code, then we parse the second number without one as well.
 		secondNumberProto := &PhoneNumber{}
-		err := parseHelper(secondNumber, "", false, false, secondNumberProto)
+		err := u.parseHelper(secondNumber, "", false, false, secondNumberProto, nil)
 		if err != nil {
 			return NOT_A_NUMBER
 		}
@@ -3116,8 +3257,8 @@ func IsNumberMatchWithOneNumber(
 // returns false. Does not check the number is a valid number. Note that,
 // at the moment, this method does not handle short numbers (which are
 // currently all presumed to not be diallable from outside their country).
-func CanBeInternationallyDialled(number *PhoneNumber) bool {
-	metadata := getMetadataForRegion(GetRegionCodeForNumber(number))
+func (u *Util) CanBeInternationallyDialled(number *PhoneNumber) bool {
+	metadata := u.getMetadataForRegion(u.GetRegionCodeForNumber(number))
 	if metadata == nil {
 		// Note numbers belonging to non-geographical entities
 		// (e.g. +800 numbers) are always internationally diallable,
@@ -3132,8 +3273,8 @@ func CanBeInternationallyDialled(number *PhoneNumber) bool {
 // Returns true if the supplied region supports mobile number portability.
 // Returns false for invalid, unknown or regions that don't support mobile
 // number portability.
-func IsMobileNumberPortableRegion(regionCode string) bool {
-	metadata := getMetadataForRegion(regionCode)
+func (u *Util) IsMobileNumberPortableRegion(regionCode string) bool {
+	metadata := u.getMetadataForRegion(regionCode)
 	if metadata == nil {
 		return false
 	}
@@ -136,8 +136,8 @@ func TestGetSupportedCallingCodes(t *testing.T) {
 
 func TestGetInstanceLoadBadMetadata(t *testing.T) {
 	useTestMetadata(t)
-	assert.Nil(t, getMetadataForRegion("No Such Region"))
-	assert.Nil(t, getMetadataForNonGeographicalRegion(-1))
+	assert.Nil(t, DefaultUtil().getMetadataForRegion("No Such Region"))
+	assert.Nil(t, DefaultUtil().getMetadataForNonGeographicalRegion(-1))
 }
 
 func TestGetSupportedTypesForRegion(t *testing.T) {
@@ -170,7 +170,7 @@ func TestGetSupportedTypesForNonGeoEntity(t *testing.T) {
 
 func TestGetInstanceLoadUSMetadata(t *testing.T) {
 	useTestMetadata(t)
-	metadata := getMetadataForRegion(regionCode.US)
+	metadata := DefaultUtil().getMetadataForRegion(regionCode.US)
 	assert.Equal(t, "US", metadata.GetId())
 	assert.Equal(t, int32(1), metadata.GetCountryCode())
 	assert.Equal(t, "011", metadata.GetInternationalPrefix())
@@ -193,7 +193,7 @@ func TestGetInstanceLoadUSMetadata(t *testing.T) {
 
 func TestGetInstanceLoadDEMetadata(t *testing.T) {
 	useTestMetadata(t)
-	metadata := getMetadataForRegion(regionCode.DE)
+	metadata := DefaultUtil().getMetadataForRegion(regionCode.DE)
 	assert.Equal(t, "DE", metadata.GetId())
 	assert.Equal(t, int32(49), metadata.GetCountryCode())
 	assert.Equal(t, "00", metadata.GetInternationalPrefix())
@@ -218,7 +218,7 @@ func TestGetInstanceLoadDEMetadata(t *testing.T) {
 // testGetInstanceLoadARMetadata (PhoneNumberUtilTest.java:248-262)
 func TestGetInstanceLoadARMetadata(t *testing.T) {
 	useTestMetadata(t)
-	metadata := getMetadataForRegion(regionCode.AR)
+	metadata := DefaultUtil().getMetadataForRegion(regionCode.AR)
 	assert.Equal(t, "AR", metadata.GetId())
 	assert.Equal(t, int32(54), metadata.GetCountryCode())
 	assert.Equal(t, "00", metadata.GetInternationalPrefix())
@@ -234,7 +234,7 @@ func TestGetInstanceLoadARMetadata(t *testing.T) {
 // testGetInstanceLoadInternationalTollFreeMetadata (PhoneNumberUtilTest.java:264-273)
 func TestGetInstanceLoadInternationalTollFreeMetadata(t *testing.T) {
 	useTestMetadata(t)
-	metadata := getMetadataForNonGeographicalRegion(800)
+	metadata := DefaultUtil().getMetadataForNonGeographicalRegion(800)
 	assert.Equal(t, "001", metadata.GetId())
 	assert.Equal(t, int32(800), metadata.GetCountryCode())
 	assert.Equal(t, "$1 $2", metadata.GetNumberFormat()[0].GetFormat())
@@ -1731,12 +1731,12 @@ func TestMaybeStripInternationalPrefix(t *testing.T) {
 // testMaybeExtractCountryCode (PhoneNumberUtilTest.java:1962-2090)
 func TestMaybeExtractCountryCode(t *testing.T) {
 	useTestMetadata(t)
-	metadata := getMetadataForRegion(regionCode.US)
+	metadata := DefaultUtil().getMetadataForRegion(regionCode.US)
 
 	// Note that for the US, the IDD is 011.
 	number := &PhoneNumber{}
 	numberToFill := stringbuilder.New(nil)
-	cc, err := maybeExtractCountryCode("011112-3456789", metadata, numberToFill, true, number)
+	cc, err := DefaultUtil().maybeExtractCountryCode("011112-3456789", metadata, numberToFill, true, number, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, cc, "Did not extract country calling code 1 correctly.")
 	assert.Equal(t, PhoneNumber_FROM_NUMBER_WITH_IDD, number.GetCountryCodeSource(), "Did not figure out CountryCodeSource correctly")
@@ -1745,54 +1745,54 @@ func TestMaybeExtractCountryCode(t *testing.T) {
 
 	number = &PhoneNumber{}
 	numberToFill = stringbuilder.New(nil)
-	cc, err = maybeExtractCountryCode("+6423456789", metadata, numberToFill, true, number)
+	cc, err = DefaultUtil().maybeExtractCountryCode("+6423456789", metadata, numberToFill, true, number, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 64, cc, "Did not extract country calling code 64 correctly.")
 	assert.Equal(t, PhoneNumber_FROM_NUMBER_WITH_PLUS_SIGN, number.GetCountryCodeSource(), "Did not figure out CountryCodeSource correctly")
 
 	number = &PhoneNumber{}
 	numberToFill = stringbuilder.New(nil)
-	cc, err = maybeExtractCountryCode("+80012345678", metadata, numberToFill, true, number)
+	cc, err = DefaultUtil().maybeExtractCountryCode("+80012345678", metadata, numberToFill, true, number, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 800, cc, "Did not extract country calling code 800 correctly.")
 	assert.Equal(t, PhoneNumber_FROM_NUMBER_WITH_PLUS_SIGN, number.GetCountryCodeSource(), "Did not figure out CountryCodeSource correctly")
 
 	number = &PhoneNumber{}
 	numberToFill = stringbuilder.New(nil)
-	cc, err = maybeExtractCountryCode("2345-6789", metadata, numberToFill, true, number)
+	cc, err = DefaultUtil().maybeExtractCountryCode("2345-6789", metadata, numberToFill, true, number, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 0, cc, "Should not have extracted a country calling code - no international prefix present.")
 	assert.Equal(t, PhoneNumber_FROM_DEFAULT_COUNTRY, number.GetCountryCodeSource(), "Did not figure out CountryCodeSource correctly")
 
 	number = &PhoneNumber{}
 	numberToFill = stringbuilder.New(nil)
-	_, err = maybeExtractCountryCode("0119991123456789", metadata, numberToFill, true, number)
+	_, err = DefaultUtil().maybeExtractCountryCode("0119991123456789", metadata, numberToFill, true, number, nil)
 	assert.ErrorIs(t, err, ErrInvalidCountryCode, "Should have thrown an exception, no valid country calling code present.")
 
 	number = &PhoneNumber{}
 	numberToFill = stringbuilder.New(nil)
-	cc, err = maybeExtractCountryCode("(1 610) 619 4466", metadata, numberToFill, true, number)
+	cc, err = DefaultUtil().maybeExtractCountryCode("(1 610) 619 4466", metadata, numberToFill, true, number, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, cc, "Should have extracted the country calling code of the region passed in")
 	assert.Equal(t, PhoneNumber_FROM_NUMBER_WITHOUT_PLUS_SIGN, number.GetCountryCodeSource(), "Did not figure out CountryCodeSource correctly")
 
 	number = &PhoneNumber{}
 	numberToFill = stringbuilder.New(nil)
-	cc, err = maybeExtractCountryCode("(1 610) 619 4466", metadata, numberToFill, false, number)
+	cc, err = DefaultUtil().maybeExtractCountryCode("(1 610) 619 4466", metadata, numberToFill, false, number, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, cc, "Should have extracted the country calling code of the region passed in")
 	assert.Nil(t, number.CountryCodeSource, "Should not contain CountryCodeSource.")
 
 	number = &PhoneNumber{}
 	numberToFill = stringbuilder.New(nil)
-	cc, err = maybeExtractCountryCode("(1 610) 619 446", metadata, numberToFill, false, number)
+	cc, err = DefaultUtil().maybeExtractCountryCode("(1 610) 619 446", metadata, numberToFill, false, number, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 0, cc, "Should not have extracted a country calling code - invalid number after extraction of uncertain country calling code.")
 	assert.Nil(t, number.CountryCodeSource, "Should not contain CountryCodeSource.")
 
 	number = &PhoneNumber{}
 	numberToFill = stringbuilder.New(nil)
-	cc, err = maybeExtractCountryCode("(1 610) 619", metadata, numberToFill, true, number)
+	cc, err = DefaultUtil().maybeExtractCountryCode("(1 610) 619", metadata, numberToFill, true, number, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 0, cc, "Should not have extracted a country calling code - too short number both before and after extraction of uncertain country calling code.")
 	assert.Equal(t, PhoneNumber_FROM_DEFAULT_COUNTRY, number.GetCountryCodeSource(), "Did not figure out CountryCodeSource correctly")
@@ -2899,17 +2899,17 @@ func TestIsMobileNumberPortableRegion(t *testing.T) {
 // testGetMetadataForRegionForNonGeoEntity_shouldBeNull (PhoneNumberUtilTest.java:3249-3251)
 func TestGetMetadataForRegionForNonGeoEntityShouldBeNull(t *testing.T) {
 	useTestMetadata(t)
-	assert.Nil(t, getMetadataForRegion(regionCode.UN001))
+	assert.Nil(t, DefaultUtil().getMetadataForRegion(regionCode.UN001))
 }
 
 // testGetMetadataForRegionForUnknownRegion_shouldBeNull (PhoneNumberUtilTest.java:3253-3255)
 func TestGetMetadataForRegionForUnknownRegionShouldBeNull(t *testing.T) {
 	useTestMetadata(t)
-	assert.Nil(t, getMetadataForRegion(regionCode.ZZ))
+	assert.Nil(t, DefaultUtil().getMetadataForRegion(regionCode.ZZ))
 }
 
 // testGetMetadataForNonGeographicalRegionForGeoRegion_shouldBeNull (PhoneNumberUtilTest.java:3257-3259)
 func TestGetMetadataForNonGeographicalRegionForGeoRegionShouldBeNull(t *testing.T) {
 	useTestMetadata(t)
-	assert.Nil(t, getMetadataForNonGeographicalRegion(1))
+	assert.Nil(t, DefaultUtil().getMetadataForNonGeographicalRegion(1))
 }
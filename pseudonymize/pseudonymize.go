@@ -0,0 +1,191 @@
+// Package pseudonymize turns phone numbers into keyed tokens, so that datasets
+// can be joined on numbers without storing them. Tokens are HMAC-SHA256 digests
+// of the number in canonical form, so the same number always gets the same
+// token under the same key, but tokens can't be reversed without the key.
+//
+// Tokens name the key that made them, so keys can be rotated: a Pseudonymizer
+// makes new tokens with its current key, and still recognizes tokens made with
+// the previous keys it is given.
+package pseudonymize
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nyaruka/phonenumbers/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// separator separates the parts of a token.
+const separator = ":"
+
+// Key is a secret key for making tokens.
+type Key struct {
+	// ID identifies the key within tokens. It must be non-empty and can't
+	// contain a colon.
+	ID string
+
+	// Secret is the HMAC key. It should be at least 32 random bytes.
+	Secret []byte
+}
+
+// Pseudonymizer makes and checks tokens for numbers.
+type Pseudonymizer struct {
+	// Util, if not nil, is used to format numbers and find their regions,
+	// rather than the default Util, e.g. so that prefixes don't change when
+	// the active metadata is reloaded.
+	Util *phonenumbers.Util
+
+	keys []Key
+}
+
+// New returns a Pseudonymizer which makes tokens with current, and also
+// recognizes tokens made with any of the previous keys.
+func New(current Key, previous ...Key) (*Pseudonymizer, error) {
+	keys := append([]Key{current}, previous...)
+	seen := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		if k.ID == "" || strings.Contains(k.ID, separator) {
+			return nil, fmt.Errorf("invalid key ID %q", k.ID)
+		}
+		if len(k.Secret) == 0 {
+			return nil, fmt.Errorf("key %s has no secret", k.ID)
+		}
+		if seen[k.ID] {
+			return nil, fmt.Errorf("duplicate key ID %s", k.ID)
+		}
+		seen[k.ID] = true
+	}
+	return &Pseudonymizer{keys: keys}, nil
+}
+
+func (p *Pseudonymizer) util() *phonenumbers.Util {
+	if p.Util != nil {
+		return p.Util
+	}
+	return phonenumbers.DefaultUtil()
+}
+
+// Token returns the token for number made with the current key: the key's ID
+// and the base64url-encoded digest, separated by a colon.
+func (p *Pseudonymizer) Token(number *phonenumbers.PhoneNumber) string {
+	return makeToken(p.keys[0], p.Canonical(number))
+}
+
+// PrefixedToken returns the token for number made with the current key,
+// prefixed with the number's country calling code and region, e.g.
+// "+44:GB:k2:...", so that tokens can still be aggregated by country.
+func (p *Pseudonymizer) PrefixedToken(number *phonenumbers.PhoneNumber) string {
+	return p.Prefix(number) + p.Token(number)
+}
+
+// Tokens returns the tokens for number made with each key, current first, to
+// look up records tokenized before a key rotation.
+func (p *Pseudonymizer) Tokens(number *phonenumbers.PhoneNumber) []string {
+	canonical := p.Canonical(number)
+	tokens := make([]string, len(p.keys))
+	for i, k := range p.keys {
+		tokens[i] = makeToken(k, canonical)
+	}
+	return tokens
+}
+
+// Verify reports whether token, plain or prefixed, was made for number with
+// any of the keys.
+func (p *Pseudonymizer) Verify(number *phonenumbers.PhoneNumber, token string) bool {
+	prefix, keyID, ok := splitToken(token)
+	if !ok || (prefix != "" && prefix != p.Prefix(number)) {
+		return false
+	}
+	for _, k := range p.keys {
+		if k.ID == keyID {
+			return hmac.Equal([]byte(token[len(prefix):]), []byte(makeToken(k, p.Canonical(number))))
+		}
+	}
+	return false
+}
+
+// IsCurrent reports whether token was made with the current key. Tokens
+// which aren't should be replaced after a key rotation.
+func (p *Pseudonymizer) IsCurrent(token string) bool {
+	_, keyID, ok := splitToken(token)
+	return ok && keyID == p.keys[0].ID
+}
+
+// Prefix returns the prefix PrefixedToken adds to the tokens of number, e.g.
+// "+44:GB:". The region is "ZZ" if the number doesn't belong to one.
+func (p *Pseudonymizer) Prefix(number *phonenumbers.PhoneNumber) string {
+	return prefix(p.util(), number)
+}
+
+// Canonical returns the form of number that tokens are made from, as the
+// package-level Canonical but formatted with p's Util.
+func (p *Pseudonymizer) Canonical(number *phonenumbers.PhoneNumber) string {
+	return canonical(p.util(), number)
+}
+
+// Prefix returns the prefix a Pseudonymizer using the default Util adds to the
+// tokens of number, e.g. "+44:GB:".
+func Prefix(number *phonenumbers.PhoneNumber) string {
+	return prefix(phonenumbers.DefaultUtil(), number)
+}
+
+func prefix(u *phonenumbers.Util, number *phonenumbers.PhoneNumber) string {
+	region := u.GetRegionCodeForNumber(number)
+	if region == "" {
+		region = "ZZ"
+	}
+	return "+" + strconv.Itoa(int(number.GetCountryCode())) + separator + region + separator
+}
+
+// Canonical returns the form of number that tokens are made from: its E164
+// format, followed by any extension as in RFC3966. Like PhoneNumberUtil's
+// copyCoreFieldsOnly, only the number itself and its extension are used, so
+// that the raw input, country code source and carrier code don't change the
+// token. Numbers are formatted with the default Util.
+func Canonical(number *phonenumbers.PhoneNumber) string {
+	return canonical(phonenumbers.DefaultUtil(), number)
+}
+
+func canonical(u *phonenumbers.Util, number *phonenumbers.PhoneNumber) string {
+	core := &phonenumbers.PhoneNumber{
+		CountryCode:    proto.Int32(number.GetCountryCode()),
+		NationalNumber: proto.Uint64(number.GetNationalNumber()),
+	}
+	if number.GetItalianLeadingZero() {
+		core.ItalianLeadingZero = proto.Bool(true)
+		if number.NumberOfLeadingZeros != nil {
+			core.NumberOfLeadingZeros = proto.Int32(number.GetNumberOfLeadingZeros())
+		}
+	}
+
+	formatted := u.Format(core, phonenumbers.E164)
+	if number.GetExtension() != "" {
+		formatted += ";ext=" + number.GetExtension()
+	}
+	return formatted
+}
+
+// makeToken makes the plain token for the canonical form of a number with k.
+func makeToken(k Key, canonical string) string {
+	mac := hmac.New(sha256.New, k.Secret)
+	mac.Write([]byte(canonical))
+	return k.ID + separator + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// splitToken splits a plain or prefixed token into its prefix, including its
+// trailing separator, and the ID of the key that made it.
+func splitToken(token string) (prefix, keyID string, ok bool) {
+	parts := strings.Split(token, separator)
+	switch len(parts) {
+	case 2:
+		return "", parts[0], true
+	case 4:
+		return parts[0] + separator + parts[1] + separator, parts[2], true
+	}
+	return "", "", false
+}
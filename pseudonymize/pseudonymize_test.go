@@ -0,0 +1,159 @@
+package pseudonymize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nyaruka/phonenumbers/v2"
+	"github.com/nyaruka/phonenumbers/v2/metadata/metadataxml"
+)
+
+func mustParse(t *testing.T, s string) *phonenumbers.PhoneNumber {
+	t.Helper()
+	num, err := phonenumbers.ParseAndKeepRawInput(s, "GB")
+	if err != nil {
+		t.Fatalf("Failed to parse number %s: %s", s, err)
+	}
+	return num
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		num      string
+		expected string
+	}{
+		{num: "020 8366 1177", expected: "+442083661177"},
+		{num: "+44 (0) 20 8366 1177", expected: "+442083661177"},
+		{num: "020 8366 1177 ext. 123", expected: "+442083661177;ext=123"},
+		{num: "+39 02 3661 8300", expected: "+390236618300"},
+	}
+	for _, test := range tests {
+		if canonical := Canonical(mustParse(t, test.num)); canonical != test.expected {
+			t.Errorf("Expected '%s', got '%s' for '%s'", test.expected, canonical, test.num)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		keys []Key
+		err  string
+	}{
+		{keys: []Key{{ID: "", Secret: []byte("secret")}}, err: `invalid key ID ""`},
+		{keys: []Key{{ID: "k:1", Secret: []byte("secret")}}, err: `invalid key ID "k:1"`},
+		{keys: []Key{{ID: "k1"}}, err: "key k1 has no secret"},
+		{keys: []Key{{ID: "k1", Secret: []byte("a")}, {ID: "k1", Secret: []byte("b")}}, err: "duplicate key ID k1"},
+	}
+	for _, test := range tests {
+		_, err := New(test.keys[0], test.keys[1:]...)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("Expected error '%s', got '%v'", test.err, err)
+		}
+	}
+}
+
+func TestTokens(t *testing.T) {
+	k1 := Key{ID: "k1", Secret: []byte("first secret")}
+	k2 := Key{ID: "k2", Secret: []byte("second secret")}
+
+	p1, err := New(k1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2, err := New(k2, k1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gb := mustParse(t, "020 8366 1177")
+	gbAgain := mustParse(t, "+44 20 8366 1177")
+	gbOther := mustParse(t, "020 8366 1178")
+
+	// the same number gets the same token however it was written
+	token := p1.Token(gb)
+	if !strings.HasPrefix(token, "k1:") || len(token) != len("k1:")+43 {
+		t.Errorf("Unexpected token '%s'", token)
+	}
+	if p1.Token(gbAgain) != token {
+		t.Errorf("Expected same token for '%s'", gbAgain.GetRawInput())
+	}
+	if p1.Token(gbOther) == token {
+		t.Errorf("Expected different token for '%s'", gbOther.GetRawInput())
+	}
+
+	prefixed := p1.PrefixedToken(gb)
+	if prefixed != "+44:GB:"+token {
+		t.Errorf("Unexpected prefixed token '%s'", prefixed)
+	}
+
+	// after rotation new tokens use the new key, but old tokens still verify
+	if p2.Token(gb) == token || !strings.HasPrefix(p2.Token(gb), "k2:") {
+		t.Errorf("Expected new token after rotation, got '%s'", p2.Token(gb))
+	}
+	if tokens := p2.Tokens(gb); len(tokens) != 2 || tokens[0] != p2.Token(gb) || tokens[1] != token {
+		t.Errorf("Unexpected tokens %v", tokens)
+	}
+	for _, tok := range []string{token, prefixed, p2.Token(gb), p2.PrefixedToken(gb)} {
+		if !p2.Verify(gb, tok) {
+			t.Errorf("Expected '%s' to verify", tok)
+		}
+		if p2.Verify(gbOther, tok) {
+			t.Errorf("Expected '%s' not to verify for other number", tok)
+		}
+	}
+	if p1.Verify(gb, p2.Token(gb)) {
+		t.Errorf("Expected token with unknown key not to verify")
+	}
+	for _, tok := range []string{"", "k1", "+1:US:" + token, "a:b:c"} {
+		if p2.Verify(gb, tok) {
+			t.Errorf("Expected '%s' not to verify", tok)
+		}
+	}
+
+	if p2.IsCurrent(token) || !p2.IsCurrent(p2.PrefixedToken(gb)) {
+		t.Errorf("Unexpected IsCurrent result")
+	}
+}
+
+func TestUtil(t *testing.T) {
+	mc, err := metadataxml.Load(strings.NewReader(`<phoneNumberMetadata><territories>
+<territory id="XX" countryCode="999" internationalPrefix="00">
+  <generalDesc><nationalNumberPattern>\d{7}</nationalNumberPattern></generalDesc>
+  <fixedLine><possibleLengths national="7"/><nationalNumberPattern>\d{7}</nationalNumberPattern></fixedLine>
+</territory>
+</territories></phoneNumberMetadata>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := phonenumbers.NewUtil(mc)
+
+	p, err := New(Key{ID: "k1", Secret: []byte("first secret")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Util = u
+
+	num, err := u.Parse("1234567", "XX")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the region and canonical form come from p's Util, not the default one
+	if prefix := p.Prefix(num); prefix != "+999:XX:" {
+		t.Errorf("Expected prefix '+999:XX:', got '%s'", prefix)
+	}
+	if prefix := Prefix(num); prefix != "+999:ZZ:" {
+		t.Errorf("Expected prefix '+999:ZZ:', got '%s'", prefix)
+	}
+	if canonical := p.Canonical(num); canonical != "+9991234567" {
+		t.Errorf("Expected canonical '+9991234567', got '%s'", canonical)
+	}
+
+	token := p.PrefixedToken(num)
+	if !strings.HasPrefix(token, "+999:XX:k1:") {
+		t.Errorf("Unexpected token '%s'", token)
+	}
+	if !p.Verify(num, token) {
+		t.Errorf("Expected token '%s' to verify", token)
+	}
+}
@@ -0,0 +1,156 @@
+package phonenumbers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RedactMode is how Redact hides the digits of a number.
+type RedactMode int
+
+const (
+	// REDACT_FULL masks every digit of the national significant number.
+	REDACT_FULL RedactMode = iota
+	// REDACT_KEEP_LAST masks all but the last RedactPolicy.KeepLast digits.
+	REDACT_KEEP_LAST
+	// REDACT_KEEP_NDC masks all but the national destination code, as given
+	// by GetLengthOfNationalDestinationCode.
+	REDACT_KEEP_NDC
+	// REDACT_HASH replaces the national significant number with a salted
+	// hash of the whole number, so that redacted numbers can still be told
+	// apart.
+	REDACT_HASH
+)
+
+// redactMask is the character masked digits are replaced with.
+const redactMask = '*'
+
+// RedactPolicy is the policy Redact applies.
+type RedactPolicy struct {
+	Mode RedactMode
+
+	// KeepLast is the number of trailing digits REDACT_KEEP_LAST leaves
+	// unmasked.
+	KeepLast int
+
+	// Salt is prepended to the number before REDACT_HASH hashes it. Without
+	// a secret salt, hashes of numbers are easily reversed by brute force.
+	Salt []byte
+}
+
+// Redact returns number in a form safe for logs, which keeps its country
+// calling code but hides its subscriber digits according to policy. Other
+// than with REDACT_HASH, digits are masked within the INTERNATIONAL format of
+// the number, e.g. "+44 20 **** ****" with REDACT_KEEP_NDC. Extensions are
+// dropped. Redact returns the empty string for a nil number.
+func (u *Util) Redact(number *PhoneNumber, policy RedactPolicy) string {
+	if number == nil {
+		return ""
+	}
+
+	number = copyCoreFieldsOnly(number)
+	number.Extension = nil
+	prefix := string(plusSign) + strconv.Itoa(int(number.GetCountryCode()))
+
+	if policy.Mode == REDACT_HASH {
+		h := sha256.New()
+		h.Write(policy.Salt)
+		h.Write([]byte(u.Format(number, E164)))
+		return prefix + " #" + hex.EncodeToString(h.Sum(nil)[:8])
+	}
+
+	nationalSignificantNumber := GetNationalSignificantNumber(number)
+	keepFirst, keepLast := 0, 0
+	switch policy.Mode {
+	case REDACT_KEEP_LAST:
+		keepLast = policy.KeepLast
+	case REDACT_KEEP_NDC:
+		keepFirst = u.GetLengthOfNationalDestinationCode(number)
+	}
+
+	// numbers with an invalid country calling code are formatted without it
+	national, found := strings.CutPrefix(u.Format(number, INTERNATIONAL), prefix)
+	if !found {
+		national = " " + nationalSignificantNumber
+	}
+
+	masked := []byte(national)
+	digit := 0
+	for i, c := range masked {
+		if c < '0' || c > '9' {
+			continue
+		}
+		if digit >= keepFirst && digit < len(nationalSignificantNumber)-keepLast {
+			masked[i] = redactMask
+		}
+		digit++
+	}
+	return prefix + string(masked)
+}
+
+// RedactNumbers returns text with each number FindNumbersWithLeniency finds in
+// it replaced by the result of calling replacer with the match, e.g. to scrub
+// numbers from messages before they are exported:
+//
+//	scrubbed := phonenumbers.RedactNumbers(body, "US", phonenumbers.VALID, func(m *phonenumbers.PhoneNumberMatch) string {
+//		return phonenumbers.Redact(m.Number(), phonenumbers.RedactPolicy{Mode: phonenumbers.REDACT_KEEP_LAST, KeepLast: 2})
+//	})
+func (u *Util) RedactNumbers(text, defaultRegion string, leniency Leniency, replacer func(*PhoneNumberMatch) string) string {
+	var sb strings.Builder
+	last := 0
+	for m := range u.FindNumbersWithLeniency(text, defaultRegion, leniency, math.MaxInt) {
+		sb.WriteString(text[last:m.Start()])
+		sb.WriteString(replacer(m))
+		last = m.End()
+	}
+	if last == 0 {
+		// no numbers found
+		return text
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+// RedactedNumber wraps a PhoneNumber so that it is only ever printed or
+// logged in redacted form. With log/slog, it logs as a group of the redacted
+// number and the number's region and type:
+//
+//	slog.Info("sending SMS", "to", phonenumbers.RedactedNumber{Number: num})
+type RedactedNumber struct {
+	Number *PhoneNumber
+	Policy RedactPolicy
+
+	// Util is the Util the number is formatted and described with. If nil,
+	// the default Util is used.
+	Util *Util
+}
+
+func (n *RedactedNumber) util() *Util {
+	if n.Util != nil {
+		return n.Util
+	}
+	return DefaultUtil()
+}
+
+// String returns the redacted number.
+func (n RedactedNumber) String() string {
+	return n.util().Redact(n.Number, n.Policy)
+}
+
+// LogValue implements slog.LogValuer.
+func (n RedactedNumber) LogValue() slog.Value {
+	if n.Number == nil {
+		return slog.Value{}
+	}
+
+	u := n.util()
+	return slog.GroupValue(
+		slog.String("number", u.Redact(n.Number, n.Policy)),
+		slog.String("region", u.GetRegionCodeForNumber(n.Number)),
+		slog.String("type", numberTypeName(u.GetNumberType(n.Number))),
+	)
+}
@@ -0,0 +1,96 @@
+package phonenumbers
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/nyaruka/phonenumbers/v2/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRedact(t *testing.T) {
+	useTestMetadata(t)
+
+	tests := []struct {
+		number   *PhoneNumber
+		policy   RedactPolicy
+		expected string
+	}{
+		{usNumber(), RedactPolicy{}, "+1 *** *** ****"},
+		{usNumber(), RedactPolicy{Mode: REDACT_KEEP_LAST, KeepLast: 4}, "+1 *** *** 0000"},
+		{usNumber(), RedactPolicy{Mode: REDACT_KEEP_NDC}, "+1 650 *** ****"},
+		{gbNumber(), RedactPolicy{Mode: REDACT_KEEP_NDC}, "+44 20 **** ****"},
+		{itNumber(), RedactPolicy{Mode: REDACT_KEEP_NDC}, "+39 02 **** ****"},
+		{itNumber(), RedactPolicy{Mode: REDACT_KEEP_LAST, KeepLast: 20}, "+39 02 3661 8300"},
+		{pn(999, 12345), RedactPolicy{Mode: REDACT_KEEP_LAST, KeepLast: 2}, "+999 ***45"},
+		{nil, RedactPolicy{}, ""},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, Redact(tc.number, tc.policy), "redact mismatch for %v", tc.number)
+	}
+
+	// extensions are dropped
+	withExtension := usNumber()
+	withExtension.Extension = proto.String("1234")
+	assert.Equal(t, "+1 *** *** ****", Redact(withExtension, RedactPolicy{}))
+
+	// hashes depend on the number and salt
+	hash := Redact(usNumber(), RedactPolicy{Mode: REDACT_HASH, Salt: []byte("salt")})
+	assert.Regexp(t, `^\+1 #[0-9a-f]{16}$`, hash)
+	assert.Equal(t, hash, Redact(withExtension, RedactPolicy{Mode: REDACT_HASH, Salt: []byte("salt")}))
+	assert.NotEqual(t, hash, Redact(usNumber(), RedactPolicy{Mode: REDACT_HASH, Salt: []byte("pepper")}))
+	assert.NotEqual(t, hash, Redact(usPremium(), RedactPolicy{Mode: REDACT_HASH, Salt: []byte("salt")}))
+
+	// RedactedNumber prints and logs only the redacted number
+	redacted := RedactedNumber{Number: usNumber(), Policy: RedactPolicy{Mode: REDACT_KEEP_NDC}}
+	assert.Equal(t, "+1 650 *** ****", fmt.Sprint(redacted))
+
+	var sb strings.Builder
+	logger := slog.New(slog.NewJSONHandler(&sb, &slog.HandlerOptions{ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey && len(groups) == 0 {
+			return slog.Attr{}
+		}
+		return a
+	}}))
+	logger.Info("sending", "to", redacted)
+	assert.JSONEq(t, `{"level": "INFO", "msg": "sending", "to": {"number": "+1 650 *** ****", "region": "US", "type": "FIXED_LINE_OR_MOBILE"}}`, sb.String())
+
+	// numbers can be described against other metadata
+	coll, ccToRegion := syntheticCollection()
+	mc, err := metadata.NewContainer(coll, ccToRegion)
+	require.NoError(t, err)
+	synthetic := NewUtil(mc)
+	num, err := synthetic.Parse("1234567", "XX")
+	require.NoError(t, err)
+	sb.Reset()
+	logger.Info("sending", "to", RedactedNumber{Number: num, Util: synthetic})
+	assert.JSONEq(t, `{"level": "INFO", "msg": "sending", "to": {"number": "+999 *******", "region": "XX", "type": "FIXED_LINE"}}`, sb.String())
+}
+
+func TestRedactNumbers(t *testing.T) {
+	useTestMetadata(t)
+
+	text := "Call 650 253 0000 or +44 20 7031 3000 today, not 1234."
+
+	mask := func(m *PhoneNumberMatch) string { return strings.Repeat("*", len(m.RawString())) }
+	assert.Equal(t, "Call ************ or **************** today, not 1234.", RedactNumbers(text, regionCode.US, VALID, mask))
+
+	e164 := func(m *PhoneNumberMatch) string { return Format(m.Number(), E164) }
+	assert.Equal(t, "Call +16502530000 or +442070313000 today, not 1234.", RedactNumbers(text, regionCode.US, VALID, e164))
+
+	redact := func(m *PhoneNumberMatch) string {
+		return Redact(m.Number(), RedactPolicy{Mode: REDACT_KEEP_LAST, KeepLast: 2})
+	}
+	assert.Equal(t, "Call +1 *** *** **00 or +44 ** **** **00 today, not 1234.", RedactNumbers(text, regionCode.US, VALID, redact))
+
+	// without a default region only the international number is found
+	assert.Equal(t, "Call 650 253 0000 or +442070313000 today, not 1234.", RedactNumbers(text, regionCode.ZZ, VALID, e164))
+
+	// numbers at the very start and end
+	assert.Equal(t, "+16502530000", RedactNumbers("650-253-0000", regionCode.US, VALID, e164))
+	assert.Equal(t, "no numbers here", RedactNumbers("no numbers here", regionCode.US, VALID, e164))
+}
@@ -103,8 +103,8 @@ func (c ShortNumberCost) String() string {
 // multiple regions, this returns true if it's possible in any of them. This provides a more
 // lenient check than #isValidShortNumber.
 // See IsPossibleShortNumberForRegion(PhoneNumber, string) for details.
-func IsPossibleShortNumber(number *PhoneNumber) bool {
-	regionsCodes := GetRegionCodesForCountryCode(int(number.GetCountryCode()))
+func (u *Util) IsPossibleShortNumber(number *PhoneNumber) bool {
+	regionsCodes := u.GetRegionCodesForCountryCode(int(number.GetCountryCode()))
 	shortNumberLength := len(GetNationalSignificantNumber(number))
 	for _, region := range regionsCodes {
 		phoneMetadata := getShortNumberMetadataForRegion(region)
@@ -120,8 +120,8 @@ func IsPossibleShortNumber(number *PhoneNumber) bool {
 
 // Check whether a short number is a possible number when dialed from the given region. This
 // provides a more lenient check than IsValidShortNumberForRegion.
-func IsPossibleShortNumberForRegion(number *PhoneNumber, regionDialingFrom string) bool {
-	if !regionDialingFromMatchesNumber(number, regionDialingFrom) {
+func (u *Util) IsPossibleShortNumberForRegion(number *PhoneNumber, regionDialingFrom string) bool {
+	if !u.regionDialingFromMatchesNumber(number, regionDialingFrom) {
 		return false
 	}
 	phoneMetadata := getShortNumberMetadataForRegion(regionDialingFrom)
@@ -136,21 +136,21 @@ func IsPossibleShortNumberForRegion(number *PhoneNumber, regionDialingFrom strin
 // multiple regions, this returns true if it's valid in any of them. Note that this doesn't verify
 // the number is actually in use, which is impossible to tell by just looking at the number
 // itself. See IsValidShortNumberForRegion(PhoneNumber, String) for details.
-func IsValidShortNumber(number *PhoneNumber) bool {
-	regionCodes := GetRegionCodesForCountryCode(int(number.GetCountryCode()))
+func (u *Util) IsValidShortNumber(number *PhoneNumber) bool {
+	regionCodes := u.GetRegionCodesForCountryCode(int(number.GetCountryCode()))
 	regionCode := getRegionCodeForShortNumberFromRegionList(number, regionCodes)
 	if len(regionCodes) > 1 && regionCode != "" {
 		// If a matching region had been found for the phone number from among two or more regions,
 		// then we have already implicitly verified its validity for that region.
 		return true
 	}
-	return IsValidShortNumberForRegion(number, regionCode)
+	return u.IsValidShortNumberForRegion(number, regionCode)
 }
 
 // Tests whether a short number matches a valid pattern in a region. Note that this doesn't verify
 // the number is actually in use, which is impossible to tell by just looking at the number itself.
-func IsValidShortNumberForRegion(number *PhoneNumber, regionDialingFrom string) bool {
-	if !regionDialingFromMatchesNumber(number, regionDialingFrom) {
+func (u *Util) IsValidShortNumberForRegion(number *PhoneNumber, regionDialingFrom string) bool {
+	if !u.regionDialingFromMatchesNumber(number, regionDialingFrom) {
 		return false
 	}
 	phoneMetadata := getShortNumberMetadataForRegion(regionDialingFrom)
@@ -171,8 +171,8 @@ func IsValidShortNumberForRegion(number *PhoneNumber, regionDialingFrom string)
 // valid, then its validity must first be checked using IsValidShortNumberForRegion. Note that
 // emergency numbers are always considered toll-free. Returns UNKNOWN_COST if the number does not
 // match a cost category. Note that an invalid number may match any cost category.
-func GetExpectedCostForRegion(number *PhoneNumber, regionDialingFrom string) ShortNumberCost {
-	if !regionDialingFromMatchesNumber(number, regionDialingFrom) {
+func (u *Util) GetExpectedCostForRegion(number *PhoneNumber, regionDialingFrom string) ShortNumberCost {
+	if !u.regionDialingFromMatchesNumber(number, regionDialingFrom) {
 		return UNKNOWN_COST
 	}
 	// Note that regionDialingFrom may be empty, in which case phoneMetadata will also be nil.
@@ -218,17 +218,17 @@ func GetExpectedCostForRegion(number *PhoneNumber, regionDialingFrom string) Sho
 //
 // Note: If the region from which the number is dialed is known, it is highly preferable to call
 // GetExpectedCostForRegion instead.
-func GetExpectedCost(number *PhoneNumber) ShortNumberCost {
-	regionCodes := GetRegionCodesForCountryCode(int(number.GetCountryCode()))
+func (u *Util) GetExpectedCost(number *PhoneNumber) ShortNumberCost {
+	regionCodes := u.GetRegionCodesForCountryCode(int(number.GetCountryCode()))
 	if len(regionCodes) == 0 {
 		return UNKNOWN_COST
 	}
 	if len(regionCodes) == 1 {
-		return GetExpectedCostForRegion(number, regionCodes[0])
+		return u.GetExpectedCostForRegion(number, regionCodes[0])
 	}
 	cost := TOLL_FREE_COST
 	for _, regionCode := range regionCodes {
-		costForRegion := GetExpectedCostForRegion(number, regionCode)
+		costForRegion := u.GetExpectedCostForRegion(number, regionCode)
 		switch costForRegion {
 		case PREMIUM_RATE_COST:
 			return PREMIUM_RATE_COST
@@ -305,8 +305,8 @@ func getExampleShortNumberForCost(regionCode string, cost ShortNumberCost) strin
 
 // Helper method to check that the country calling code of the number matches the region it's
 // being dialed from.
-func regionDialingFromMatchesNumber(number *PhoneNumber, regionDialingFrom string) bool {
-	regionCodes := GetRegionCodesForCountryCode(int(number.GetCountryCode()))
+func (u *Util) regionDialingFromMatchesNumber(number *PhoneNumber, regionDialingFrom string) bool {
+	regionCodes := u.GetRegionCodesForCountryCode(int(number.GetCountryCode()))
 	for _, region := range regionCodes {
 		if region == regionDialingFrom {
 			return true
@@ -386,8 +386,8 @@ func ConnectsToEmergencyNumber(number string, regionCode string) bool {
 // end-point, or not connect at all, depending on the user's carrier. If it is important that the
 // number is valid, then its validity must first be checked using IsValidShortNumber or
 // IsValidShortNumberForRegion.
-func IsCarrierSpecific(number *PhoneNumber) bool {
-	regionCodes := GetRegionCodesForCountryCode(int(number.GetCountryCode()))
+func (u *Util) IsCarrierSpecific(number *PhoneNumber) bool {
+	regionCodes := u.GetRegionCodesForCountryCode(int(number.GetCountryCode()))
 	regionCode := getRegionCodeForShortNumberFromRegionList(number, regionCodes)
 	nationalNumber := GetNationalSignificantNumber(number)
 	phoneMetadata := getShortNumberMetadataForRegion(regionCode)
@@ -398,8 +398,8 @@ func IsCarrierSpecific(number *PhoneNumber) bool {
 // IsCarrierSpecificForRegion given a valid short number, determines whether it is carrier-specific
 // when dialed from the given region (however, nothing is implied about its validity). Returns false
 // if the number doesn't match the region provided.
-func IsCarrierSpecificForRegion(number *PhoneNumber, regionDialingFrom string) bool {
-	if !regionDialingFromMatchesNumber(number, regionDialingFrom) {
+func (u *Util) IsCarrierSpecificForRegion(number *PhoneNumber, regionDialingFrom string) bool {
+	if !u.regionDialingFromMatchesNumber(number, regionDialingFrom) {
 		return false
 	}
 	nationalNumber := GetNationalSignificantNumber(number)
@@ -413,8 +413,8 @@ func IsCarrierSpecificForRegion(number *PhoneNumber, regionDialingFrom string) b
 // intended usage is to receive and/or send text messages (SMSs). This includes MMS as MMS numbers
 // downgrade to SMS if the other party isn't MMS-capable. Returns false if the number doesn't match
 // the region provided.
-func IsSmsServiceForRegion(number *PhoneNumber, regionDialingFrom string) bool {
-	if !regionDialingFromMatchesNumber(number, regionDialingFrom) {
+func (u *Util) IsSmsServiceForRegion(number *PhoneNumber, regionDialingFrom string) bool {
+	if !u.regionDialingFromMatchesNumber(number, regionDialingFrom) {
 		return false
 	}
 	phoneMetadata := getShortNumberMetadataForRegion(regionDialingFrom)
@@ -0,0 +1,136 @@
+package phonenumbers
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// ErrNullNumber is returned by SQLNumber when a NULL is scanned or a nil
+// number is stored with NULL_REJECT.
+var ErrNullNumber = errors.New("phone number is null")
+
+// NullPolicy is how SQLNumber maps between NULL column values and nil
+// numbers.
+type NullPolicy int
+
+const (
+	// NULL_AS_NIL stores nil numbers as NULL, and scans NULL as a nil number.
+	NULL_AS_NIL NullPolicy = iota
+	// NULL_REJECT errors on NULL and on nil numbers.
+	NULL_REJECT
+	// NULL_AS_EMPTY_STRING stores nil numbers as the empty string, for NOT
+	// NULL columns, and scans both NULL and the empty string as a nil number.
+	NULL_AS_EMPTY_STRING
+)
+
+// UnparseablePolicy is what SQLNumber does with column values that fail to
+// parse.
+type UnparseablePolicy int
+
+const (
+	// UNPARSEABLE_REJECT returns the ParseError from Scan.
+	UNPARSEABLE_REJECT UnparseablePolicy = iota
+	// UNPARSEABLE_AS_NIL scans the value as a nil number.
+	UNPARSEABLE_AS_NIL
+	// UNPARSEABLE_KEEP_RAW_INPUT scans the value as a number with only its
+	// RawInput set, which Value stores back unchanged.
+	UNPARSEABLE_KEEP_RAW_INPUT
+)
+
+// SQLNumber wraps a PhoneNumber so it can be used directly as a database/sql
+// column value. Numbers are stored in E164 format, with any extension
+// appended as in RFC3966 (e.g. "+16502530000;ext=123"), and parsed again
+// when scanned. Other fields, such as the raw input and carrier code, aren't
+// stored.
+//
+// The zero value stores and scans NULL as a nil Number, and rejects values
+// that fail to parse. Set the policies before scanning to change that:
+//
+//	n := phonenumbers.SQLNumber{Unparseable: phonenumbers.UNPARSEABLE_AS_NIL}
+//	err := row.Scan(&n)
+type SQLNumber struct {
+	Number *PhoneNumber
+
+	// DefaultRegion is the region used to parse values without a country
+	// calling code, e.g. those written by something other than SQLNumber.
+	// If empty, such values fail to parse.
+	DefaultRegion string
+
+	Nulls       NullPolicy
+	Unparseable UnparseablePolicy
+
+	// Util is the Util numbers are parsed and formatted with. If nil, the
+	// default Util is used.
+	Util *Util
+}
+
+func (n *SQLNumber) util() *Util {
+	if n.Util != nil {
+		return n.Util
+	}
+	return DefaultUtil()
+}
+
+// Value implements driver.Valuer.
+func (n SQLNumber) Value() (driver.Value, error) {
+	if n.Number == nil {
+		switch n.Nulls {
+		case NULL_REJECT:
+			return nil, ErrNullNumber
+		case NULL_AS_EMPTY_STRING:
+			return "", nil
+		}
+		return nil, nil
+	}
+	return n.util().formatE164WithExtension(n.Number), nil
+}
+
+// Scan implements sql.Scanner.
+func (n *SQLNumber) Scan(src any) error {
+	var value string
+	switch v := src.(type) {
+	case nil:
+		if n.Nulls == NULL_REJECT {
+			return ErrNullNumber
+		}
+		n.Number = nil
+		return nil
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("unsupported type %T for phone number", src)
+	}
+
+	if value == "" && n.Nulls == NULL_AS_EMPTY_STRING {
+		n.Number = nil
+		return nil
+	}
+
+	number, err := n.util().Parse(value, n.DefaultRegion)
+	if err != nil {
+		switch n.Unparseable {
+		case UNPARSEABLE_AS_NIL:
+			n.Number = nil
+			return nil
+		case UNPARSEABLE_KEEP_RAW_INPUT:
+			n.Number = &PhoneNumber{RawInput: &value}
+			return nil
+		}
+		return err
+	}
+	n.Number = number
+	return nil
+}
+
+// formatE164WithExtension formats number in E164 format, followed by its
+// extension in RFC3966 form if it has one. Parse reads the result back.
+func (u *Util) formatE164WithExtension(number *PhoneNumber) string {
+	formatted := u.Format(number, E164)
+	if number.GetExtension() != "" && number.GetNationalNumber() != 0 {
+		formatted += rfc3966ExtnPrefix + number.GetExtension()
+	}
+	return formatted
+}
@@ -0,0 +1,89 @@
+package phonenumbers
+
+import (
+	"testing"
+
+	"github.com/nyaruka/phonenumbers/v2/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSQLNumber(t *testing.T) {
+	useTestMetadata(t)
+
+	us := usNumber()
+
+	withExtension := &PhoneNumber{CountryCode: proto.Int32(1), NationalNumber: proto.Uint64(6502530000), Extension: proto.String("123")}
+	italian := &PhoneNumber{CountryCode: proto.Int32(39), NationalNumber: proto.Uint64(236618300), ItalianLeadingZero: proto.Bool(true)}
+
+	// values round-trip through their stored form
+	for _, num := range []*PhoneNumber{us, withExtension, italian} {
+		value, err := SQLNumber{Number: num}.Value()
+		assert.NoError(t, err)
+
+		var scanned SQLNumber
+		assert.NoError(t, scanned.Scan(value))
+		assert.True(t, proto.Equal(num, scanned.Number), "round trip mismatch for %v", num)
+	}
+
+	value, err := SQLNumber{Number: withExtension}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "+16502530000;ext=123", value)
+
+	// scanning accepts bytes, and numbers in a default region
+	n := SQLNumber{DefaultRegion: regionCode.US}
+	assert.NoError(t, n.Scan([]byte("(650) 253-0000")))
+	assert.True(t, proto.Equal(us, n.Number))
+	assert.EqualError(t, n.Scan(1234), "unsupported type int for phone number")
+
+	// nulls
+	value, err = SQLNumber{}.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+	n = SQLNumber{Number: us}
+	assert.NoError(t, n.Scan(nil))
+	assert.Nil(t, n.Number)
+
+	_, err = SQLNumber{Nulls: NULL_REJECT}.Value()
+	assert.Equal(t, ErrNullNumber, err)
+	n = SQLNumber{Nulls: NULL_REJECT}
+	assert.Equal(t, ErrNullNumber, n.Scan(nil))
+
+	value, err = SQLNumber{Nulls: NULL_AS_EMPTY_STRING}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "", value)
+	n = SQLNumber{Number: us, Nulls: NULL_AS_EMPTY_STRING}
+	assert.NoError(t, n.Scan(""))
+	assert.Nil(t, n.Number)
+
+	// unparseable values
+	n = SQLNumber{Number: us}
+	err = n.Scan("not a number")
+	var pe *ParseError
+	if assert.ErrorAs(t, err, &pe) {
+		assert.Equal(t, ErrorType_NOT_A_NUMBER, pe.Type)
+	}
+
+	n = SQLNumber{Number: us, Unparseable: UNPARSEABLE_AS_NIL}
+	assert.NoError(t, n.Scan("not a number"))
+	assert.Nil(t, n.Number)
+
+	n = SQLNumber{Unparseable: UNPARSEABLE_KEEP_RAW_INPUT}
+	assert.NoError(t, n.Scan("not a number"))
+	assert.Equal(t, "not a number", n.Number.GetRawInput())
+	value, err = n.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "not a number", value)
+
+	// values can be parsed and formatted against other metadata
+	coll, ccToRegion := syntheticCollection()
+	mc, err := metadata.NewContainer(coll, ccToRegion)
+	require.NoError(t, err)
+	n = SQLNumber{DefaultRegion: "XX", Util: NewUtil(mc)}
+	assert.NoError(t, n.Scan("1234567"))
+	assert.Equal(t, int32(999), n.Number.GetCountryCode())
+	value, err = n.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "+9991234567", value)
+}
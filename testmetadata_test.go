@@ -18,8 +18,8 @@ import (
 	"sync"
 	"testing"
 
-	"github.com/nyaruka/phonenumbers/v2/internal/metadatabuilder"
 	"github.com/nyaruka/phonenumbers/v2/metadata"
+	"github.com/nyaruka/phonenumbers/v2/metadata/metadataxml"
 	"github.com/stretchr/testify/require"
 )
 
@@ -47,17 +47,13 @@ var (
 // the real metadata.
 func loadTestMetadataContainer() (*metadata.Container, error) {
 	testMetadataOnce.Do(func() {
-		xml, err := os.ReadFile("testdata/PhoneNumberMetadataForTesting.xml")
+		f, err := os.Open("testdata/PhoneNumberMetadataForTesting.xml")
 		if err != nil {
 			testMetadataErr = err
 			return
 		}
-		coll, err := metadatabuilder.BuildPhoneMetadataCollection(xml, false, false, false)
-		if err != nil {
-			testMetadataErr = err
-			return
-		}
-		testMetadataContainer, testMetadataErr = metadata.NewContainer(coll, metadatabuilder.BuildCountryCodeToRegionMap(coll))
+		defer f.Close()
+		testMetadataContainer, testMetadataErr = metadataxml.Load(f)
 	})
 	return testMetadataContainer, testMetadataErr
 }
@@ -0,0 +1,121 @@
+package phonenumbers
+
+import "slices"
+
+// Verifier decides whether a number found in text is a match, as the Leniency
+// levels do. Custom verifiers can be built from the levels and the checks
+// they are made of with AllOf and AnyOf, along with checks of their own.
+type Verifier interface {
+	// VerifyNumber reports whether number, parsed by u from candidate, is a
+	// match.
+	VerifyNumber(u *Util, number *PhoneNumber, candidate string) bool
+}
+
+// VerifierFunc adapts a function to a Verifier.
+type VerifierFunc func(u *Util, number *PhoneNumber, candidate string) bool
+
+// VerifyNumber implements Verifier.
+func (f VerifierFunc) VerifyNumber(u *Util, number *PhoneNumber, candidate string) bool {
+	return f(u, number, candidate)
+}
+
+var (
+	// HasOnlyValidXChars checks that any 'x' or 'X' in the candidate is a
+	// carrier code or extension sign, as VALID and stricter do.
+	HasOnlyValidXChars Verifier = VerifierFunc(func(u *Util, number *PhoneNumber, candidate string) bool {
+		return containsOnlyValidXChars(number, candidate, u)
+	})
+
+	// HasNationalPrefixIfRequired checks that a number written in national
+	// format has its national prefix, if it is usually written, as VALID and
+	// stricter do.
+	HasNationalPrefixIfRequired Verifier = VerifierFunc(func(u *Util, number *PhoneNumber, candidate string) bool {
+		return isNationalPrefixPresentIfRequired(number, u)
+	})
+
+	// HasStrictGrouping checks that the digits of the candidate are grouped
+	// in a possible way for its region, and it has at most one '/' in its
+	// national number, as STRICT_GROUPING does.
+	HasStrictGrouping Verifier = VerifierFunc(func(u *Util, number *PhoneNumber, candidate string) bool {
+		return !containsMoreThanOneSlashInNationalNumber(number, candidate) &&
+			checkNumberGroupingIsValid(number, candidate, u, allNumberGroupsRemainGrouped)
+	})
+
+	// HasExactGrouping checks that the digits of the candidate are grouped
+	// as the number would be formatted, or as a single block, and it has at
+	// most one '/' in its national number, as EXACT_GROUPING does.
+	HasExactGrouping Verifier = VerifierFunc(func(u *Util, number *PhoneNumber, candidate string) bool {
+		return !containsMoreThanOneSlashInNationalNumber(number, candidate) &&
+			checkNumberGroupingIsValid(number, candidate, u, allNumberGroupsAreExactlyPresent)
+	})
+)
+
+// allOf is the Verifier returned by AllOf.
+type allOf []Verifier
+
+func (a allOf) VerifyNumber(u *Util, number *PhoneNumber, candidate string) bool {
+	for _, v := range a {
+		if !v.VerifyNumber(u, number, candidate) {
+			return false
+		}
+	}
+	return true
+}
+
+// anyOf is the Verifier returned by AnyOf.
+type anyOf []Verifier
+
+func (a anyOf) VerifyNumber(u *Util, number *PhoneNumber, candidate string) bool {
+	for _, v := range a {
+		if v.VerifyNumber(u, number, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllOf returns a Verifier which matches numbers that all of verifiers match,
+// trying them in order. Numbers next to letters aren't matched if any of
+// verifiers is VALID or stricter.
+func AllOf(verifiers ...Verifier) Verifier {
+	return allOf(verifiers)
+}
+
+// AnyOf returns a Verifier which matches numbers that any of verifiers match,
+// trying them in order. Numbers next to letters aren't matched if all of
+// verifiers are VALID or stricter.
+func AnyOf(verifiers ...Verifier) Verifier {
+	return anyOf(verifiers)
+}
+
+// OfType returns a Verifier which matches numbers of any of types, as given
+// by GetNumberType. It doesn't check the number is valid, which is implied
+// by most types, but should be combined with VALID to skip numbers next to
+// letters.
+func OfType(types ...PhoneNumberType) Verifier {
+	return VerifierFunc(func(u *Util, number *PhoneNumber, candidate string) bool {
+		return slices.Contains(types, u.GetNumberType(number))
+	})
+}
+
+// InRegion returns a Verifier which matches numbers from any of regions, as
+// given by GetRegionCodeForNumber.
+func InRegion(regions ...string) Verifier {
+	return VerifierFunc(func(u *Util, number *PhoneNumber, candidate string) bool {
+		return slices.Contains(regions, u.GetRegionCodeForNumber(number))
+	})
+}
+
+// requiresSeparation reports whether verifier only matches numbers which
+// aren't next to Latin letters or currency symbols, as VALID and stricter do.
+func requiresSeparation(verifier Verifier) bool {
+	switch v := verifier.(type) {
+	case Leniency:
+		return v >= VALID
+	case allOf:
+		return slices.ContainsFunc(v, requiresSeparation)
+	case anyOf:
+		return len(v) > 0 && !slices.ContainsFunc(v, func(v Verifier) bool { return !requiresSeparation(v) })
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package phonenumbers
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifier(t *testing.T) {
+	useTestMetadata(t)
+
+	text := "Mobile +44 7912 345 678, office +44 20 7031 3000, US 650 253 0000, free +1 800 253 0000, NZ +64 21 123 456 or id7912345678."
+	find := func(verifier Verifier, maxTries int) []string {
+		var found []string
+		for m := range FindNumbersWithVerifier(text, regionCode.US, verifier, maxTries) {
+			found = append(found, m.RawString())
+		}
+		return found
+	}
+
+	// the built-in levels are verifiers
+	assert.Equal(t, slices.Collect(func(yield func(string) bool) {
+		for m := range FindNumbersWithLeniency(text, regionCode.US, VALID, math.MaxInt) {
+			if !yield(m.RawString()) {
+				return
+			}
+		}
+	}), find(VALID, math.MaxInt))
+
+	mobiles := AllOf(VALID, OfType(MOBILE, FIXED_LINE_OR_MOBILE))
+	assert.Equal(t, []string{"+44 7912 345 678", "650 253 0000", "+64 21 123 456"}, find(mobiles, math.MaxInt))
+	assert.Equal(t, []string{"+44 7912 345 678", "+44 20 7031 3000"}, find(AllOf(VALID, InRegion("GB")), math.MaxInt))
+	assert.Equal(t, []string{"+44 7912 345 678", "+1 800 253 0000", "+64 21 123 456"}, find(AllOf(VALID, AnyOf(OfType(MOBILE), OfType(TOLL_FREE))), math.MaxInt))
+
+	// numbers the verifier rejects count towards the tries
+	assert.Equal(t, []string{"+44 7912 345 678"}, find(mobiles, 1))
+	assert.Equal(t, []string{"+44 7912 345 678", "+44 20 7031 3000", "650 253 0000", "+1 800 253 0000", "+64 21 123 456"}, find(VALID, 1))
+
+	// only verifiers which include VALID or stricter skip numbers next to letters
+	assert.Contains(t, find(POSSIBLE, math.MaxInt), "7912345678")
+	assert.NotContains(t, find(AllOf(POSSIBLE, VALID), math.MaxInt), "7912345678")
+	assert.Contains(t, find(AnyOf(POSSIBLE, VALID), math.MaxInt), "7912345678")
+	assert.Contains(t, find(VerifierFunc(func(u *Util, number *PhoneNumber, candidate string) bool { return true }), math.MaxInt), "7912345678")
+
+	// the checks that make up the levels give the same matches as them
+	for _, tc := range []struct {
+		level    Leniency
+		verifier Verifier
+	}{
+		{VALID, AllOf(POSSIBLE, VerifierFunc(func(u *Util, number *PhoneNumber, candidate string) bool { return u.IsValidNumber(number) }), HasOnlyValidXChars, HasNationalPrefixIfRequired)},
+		{STRICT_GROUPING, AllOf(VALID, HasStrictGrouping)},
+		{EXACT_GROUPING, AllOf(VALID, HasExactGrouping)},
+	} {
+		for _, s := range []string{"650 253 0000", "650 2530000", "6502530000", "65 02 53 00 00", "650253 0000", "+44 20 7031 3000", "+44 2070 313 000", "020 7031 3000"} {
+			assert.Equal(t,
+				tc.level.Verify(mustParseAndKeepRawInput(t, s, regionCode.US), s),
+				tc.verifier.VerifyNumber(DefaultUtil(), mustParseAndKeepRawInput(t, s, regionCode.US), s),
+				"level %d for %s", tc.level, s)
+		}
+	}
+}